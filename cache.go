@@ -0,0 +1,187 @@
+package colorize
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// CacheStats of the color cache, as returned by ColorCacheStats().
+	CacheStats struct {
+		Hits      uint64
+		Misses    uint64
+		Evictions uint64
+		Size      int
+		Limit     int
+	}
+
+	// lruColorCache keeps the recently used colors, keyed by their packed RGBA value.
+	// The least recently used colors are approximated by the clock algorithm,
+	// so the hits only take the read lock, and mark their slot as referenced.
+	lruColorCache struct {
+		// hits, misses and evictions are updated atomically, placed first for their 64-bit alignment.
+		hits      uint64
+		misses    uint64
+		evictions uint64
+		mux       sync.RWMutex // protects all the below fields
+		limit     int
+		entries   map[uint32]int
+		slots     []lruColorCacheSlot
+		// hand of the clock, the next slot to be considered for eviction.
+		hand int
+	}
+
+	lruColorCacheSlot struct {
+		key   uint32
+		color Color
+		// referenced since the hand has passed, giving the slot a second chance.
+		referenced uint32
+	}
+)
+
+// DefaultColorCacheLimit is the count of colors kept by the cache, unless changed by SetColorCacheLimit().
+const DefaultColorCacheLimit = 4096
+
+// SetColorCacheLimit bounds the count of cached colors, evicting the ones not recently used.
+// A zero limit disables the cache, while a negative one leaves it unbounded.
+func SetColorCacheLimit(limit int) {
+	colorCache.setLimit(limit)
+}
+
+// ColorCacheStats returns the hits, misses, evictions and size of the color cache.
+func ColorCacheStats() CacheStats {
+	return colorCache.snapshot()
+}
+
+// ResetColorCache drops the cached colors, and resets the stats.
+func ResetColorCache() {
+	colorCache.reset()
+}
+
+func newLRUColorCache(limit int) *lruColorCache {
+	return &lruColorCache{
+		limit:   limit,
+		entries: make(map[uint32]int),
+	}
+}
+
+// get returns the cached color of the packed RGBA key, or creates and caches a new one.
+func (lc *lruColorCache) get(key uint32) Color {
+	lc.mux.RLock()
+	if index, ok := lc.entries[key]; ok {
+		color := lc.slots[index].color
+		lc.markReferenced(index)
+		lc.mux.RUnlock()
+		atomic.AddUint64(&lc.hits, 1)
+
+		return color
+	}
+	lc.mux.RUnlock()
+
+	lc.mux.Lock()
+	defer lc.mux.Unlock()
+
+	// Another call might have cached the color meanwhile.
+	if index, ok := lc.entries[key]; ok {
+		atomic.AddUint64(&lc.hits, 1)
+
+		return lc.slots[index].color
+	}
+
+	atomic.AddUint64(&lc.misses, 1)
+	color := createColor(byte(key>>24), byte(key>>16), byte(key>>8), byte(key))
+
+	switch {
+	case lc.limit == 0:
+	case lc.limit < 0 || len(lc.slots) < lc.limit:
+		lc.entries[key] = len(lc.slots)
+		lc.slots = append(lc.slots, lruColorCacheSlot{key: key, color: color})
+	default:
+		index := lc.victim()
+		delete(lc.entries, lc.slots[index].key)
+		atomic.AddUint64(&lc.evictions, 1)
+
+		lc.entries[key] = index
+		lc.slots[index] = lruColorCacheSlot{key: key, color: color}
+	}
+
+	return color
+}
+
+// markReferenced gives the slot a second chance, skipping the write when it is already marked,
+// so the concurrent hits of the same color do not contend on it.
+func (lc *lruColorCache) markReferenced(index int) {
+	referenced := &lc.slots[index].referenced
+	if atomic.LoadUint32(referenced) == 0 {
+		atomic.StoreUint32(referenced, 1)
+	}
+}
+
+// victim advances the hand to the first slot not referenced since its last pass, and returns it,
+// clearing the references of the passed slots.
+func (lc *lruColorCache) victim() int {
+	for {
+		index := lc.hand
+		lc.hand = (lc.hand + 1) % len(lc.slots)
+
+		if atomic.SwapUint32(&lc.slots[index].referenced, 0) == 0 {
+			return index
+		}
+	}
+}
+
+func (lc *lruColorCache) setLimit(limit int) {
+	lc.mux.Lock()
+	defer lc.mux.Unlock()
+
+	lc.limit = limit
+	if limit < 0 || len(lc.slots) <= limit {
+		return
+	}
+
+	for len(lc.entries) > limit {
+		index := lc.victim()
+		if _, ok := lc.entries[lc.slots[index].key]; !ok {
+			continue
+		}
+
+		delete(lc.entries, lc.slots[index].key)
+		lc.slots[index].color = nil
+		atomic.AddUint64(&lc.evictions, 1)
+	}
+
+	slots := make([]lruColorCacheSlot, 0, limit)
+	for _, slot := range lc.slots {
+		if slot.color != nil {
+			lc.entries[slot.key] = len(slots)
+			slots = append(slots, slot)
+		}
+	}
+	lc.slots = slots
+	lc.hand = 0
+}
+
+func (lc *lruColorCache) snapshot() CacheStats {
+	lc.mux.RLock()
+	defer lc.mux.RUnlock()
+
+	return CacheStats{
+		Hits:      atomic.LoadUint64(&lc.hits),
+		Misses:    atomic.LoadUint64(&lc.misses),
+		Evictions: atomic.LoadUint64(&lc.evictions),
+		Size:      len(lc.slots),
+		Limit:     lc.limit,
+	}
+}
+
+func (lc *lruColorCache) reset() {
+	lc.mux.Lock()
+	defer lc.mux.Unlock()
+
+	lc.entries = make(map[uint32]int)
+	lc.slots = nil
+	lc.hand = 0
+	atomic.StoreUint64(&lc.hits, 0)
+	atomic.StoreUint64(&lc.misses, 0)
+	atomic.StoreUint64(&lc.evictions, 0)
+}
@@ -0,0 +1,105 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLRUColorCache(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		limit    int
+		input    []uint32
+		expected CacheStats
+	}{
+		{
+			id:    "Should count hits and misses.",
+			limit: 4,
+			input: []uint32{0xff000000, 0x00ff0000, 0xff000000},
+			expected: CacheStats{
+				Hits:   1,
+				Misses: 2,
+				Size:   2,
+				Limit:  4,
+			},
+		},
+		{
+			id:    "Should evict the least recently used colors.",
+			limit: 2,
+			input: []uint32{0xff000000, 0x00ff0000, 0xff000000, 0x0000ff00, 0x00ff0000},
+			expected: CacheStats{
+				Hits:      1,
+				Misses:    4,
+				Evictions: 2,
+				Size:      2,
+				Limit:     2,
+			},
+		},
+		{
+			id:    "Should not cache when disabled.",
+			limit: 0,
+			input: []uint32{0xff000000, 0xff000000},
+			expected: CacheStats{
+				Misses: 2,
+			},
+		},
+		{
+			id:    "Should not evict when unbounded.",
+			limit: -1,
+			input: []uint32{0xff000000, 0x00ff0000, 0x0000ff00},
+			expected: CacheStats{
+				Misses: 3,
+				Size:   3,
+				Limit:  -1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			cache := newLRUColorCache(testCase.limit)
+
+			for _, key := range testCase.input {
+				color := cache.get(key)
+				assert.Equal(t, byte(key>>24), color.Red())
+				assert.Equal(t, byte(key>>16), color.Green())
+				assert.Equal(t, byte(key>>8), color.Blue())
+			}
+
+			assert.Equal(t, testCase.expected, cache.snapshot())
+		})
+	}
+}
+
+func TestLRUColorCacheLimit(t *testing.T) {
+	t.Parallel()
+
+	cache := newLRUColorCache(-1)
+	for key := uint32(0); key < 10; key++ {
+		cache.get(key << 8)
+	}
+
+	cache.setLimit(3)
+	assert.Equal(t, CacheStats{Misses: 10, Evictions: 7, Size: 3, Limit: 3}, cache.snapshot())
+
+	cache.get(9 << 8)
+	cache.get(0)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 11, Evictions: 8, Size: 3, Limit: 3}, cache.snapshot())
+
+	cache.reset()
+	assert.Equal(t, CacheStats{Limit: 3}, cache.snapshot())
+}
+
+func TestColorCacheStats(t *testing.T) {
+	t.Parallel()
+
+	RGB(17, 34, 51)
+	before := ColorCacheStats()
+	RGB(17, 34, 51)
+	after := ColorCacheStats()
+
+	assert.True(t, after.Hits > before.Hits)
+	assert.Equal(t, DefaultColorCacheLimit, after.Limit)
+}
@@ -3,6 +3,7 @@ package colorize
 import (
 	"fmt"
 	baseColor "image/color"
+	"strconv"
 )
 
 type (
@@ -28,7 +29,7 @@ type (
 
 	// Formatter representation interface.
 	Formatter interface {
-		generate(colorMode) string
+		appendParameters(parameters []byte, mode colorMode) []byte
 	}
 
 	// color for RGB.
@@ -40,7 +41,6 @@ type (
 )
 
 const (
-	colorModeFormat              = "%d;2;%#v"
 	colorDigitsFormat            = "%d;%d;%d"
 	colorStringFormat            = "%d.%d.%d.%d"
 	colorRGBFormat               = "%d, %d, %d"
@@ -116,8 +116,15 @@ func (clr color) format(format string, args ...interface{}) string {
 	return fmt.Sprintf(format, args...)
 }
 
-// generate returns a string color representation based on
-// given mode (foreground or background).
-func (clr color) generate(mode colorMode) string {
-	return clr.format(colorModeFormat, mode, clr)
+// appendParameters appends the SGR color parameters based on
+// given mode (foreground, background or underline), e.g. 38;2;255;0;0
+func (clr color) appendParameters(parameters []byte, mode colorMode) []byte {
+	parameters = strconv.AppendUint(parameters, uint64(mode), 10)
+	parameters = append(parameters, ';', '2', ';')
+	parameters = strconv.AppendUint(parameters, uint64(clr.Red()), 10)
+	parameters = append(parameters, ';')
+	parameters = strconv.AppendUint(parameters, uint64(clr.Green()), 10)
+	parameters = append(parameters, ';')
+
+	return strconv.AppendUint(parameters, uint64(clr.Blue()), 10)
 }
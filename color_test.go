@@ -163,6 +163,7 @@ func TestColorEquals(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 			target := testCase.input["target"]
@@ -257,6 +258,7 @@ func TestColorHex(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -285,6 +287,7 @@ func TestColorRGB(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -1,6 +1,7 @@
 package colorize
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/mattn/go-isatty"
 	baseColor "image/color"
@@ -12,28 +13,24 @@ import (
 type (
 	// Colorable wrapper for color operations.
 	Colorable struct {
-		appliedStyle  Style
+		mux          sync.Mutex // protects all the below fields
+		appliedStyle Style
+		// isStyleOpen when the applied style was opened on the output by Set(), and not reset since.
+		isStyleOpen bool
+		// pendingLink of the applied style, written without hyperlinks support since Set(),
+		// its fallback is written once the style is reset or replaced, see flushLink().
+		pendingLink   Link
 		isColorActive *bool
 		output        io.Writer
+		profile       Profile
+		lineMode      LineMode
+		// outerStyles of the nested Push() calls.
+		outerStyles []Style
+		// theme of the roles, DefaultTheme when nil, see SetTheme().
+		theme Theme
+		// palette of the named colors, pure RGB colors when nil, see SetPalette().
+		palette *Palette
 	}
-
-	// FontEffect value.
-	FontEffect int
-)
-
-// Font effects.
-// Some effects are not supported on all terminals.
-const (
-	Normal FontEffect = iota
-	Bold
-	Faint
-	Italic
-	Underline
-	BlinkSlow
-	BlinkRapid
-	ReverseVideo
-	Concealed
-	CrossedOut
 )
 
 var (
@@ -44,21 +41,25 @@ var (
 		(!isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()))
 	colorDisabledMux sync.Mutex // protects colorDisabled
 
-	// colorCache is used to reduce the count of created Style objects, and
+	// colorCache is used to reduce the count of created Color objects, and
 	// it allows to reuse already created objects with required Attribute.
-	colorCache sync.Map
+	colorCache = newLRUColorCache(DefaultColorCacheLimit)
 )
 
 // NewColorable allocates and returns a new Colorable.
 // e.g.: colorized := NewColorable(os.Stdout)
 func NewColorable(output io.Writer) *Colorable {
 	return &Colorable{
-		output: output,
+		output:  output,
+		profile: DefaultProfile,
 	}
 }
 
 // AppliedStyle returns the applied style by Set().
 func (c *Colorable) AppliedStyle() Style {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
 	return c.appliedStyle
 }
 
@@ -66,6 +67,9 @@ func (c *Colorable) AppliedStyle() Style {
 // a defined flag e.g. --no-color, so without modifying existing code
 // output is done normally but having the color disabled.
 func (c *Colorable) DisableColor() *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
 	c.isColorActive = boolPtr(false)
 
 	return c
@@ -75,13 +79,111 @@ func (c *Colorable) DisableColor() *Colorable {
 // used in conjunction with DisableColor().
 // Otherwise, it will have no side effect.
 func (c *Colorable) EnableColor() *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
 	c.isColorActive = boolPtr(true)
 
 	return c
 }
 
+// Profile returns the terminal profile, styles are degraded to.
+func (c *Colorable) Profile() Profile {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.profile
+}
+
+// SetProfile sets the terminal profile, used to degrade the styles
+// to what the terminal is able to render, e.g. SetProfile(BasicProfile).
+func (c *Colorable) SetProfile(profile Profile) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.profile = profile
+
+	return c
+}
+
+// LineMode returns how the styled text spans over multiple lines.
+func (c *Colorable) LineMode() LineMode {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.lineMode
+}
+
+// SetLineMode sets how the styled text spans over multiple lines,
+// e.g. SetLineMode(ResetLines) to wrap each line independently.
+func (c *Colorable) SetLineMode(mode LineMode) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.lineMode = mode
+
+	return c
+}
+
+// Palette returns the palette of the named colors, and false if it is not set.
+func (c *Colorable) Palette() (Palette, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.palette == nil {
+		return Palette{}, false
+	}
+
+	return *c.palette, true
+}
+
+// SetPalette sets the palette followed by the named colors, as of Red() and the markup tags,
+// instead of their pure RGB values, e.g. SetPalette(DraculaPalette).
+// Orange and purple, missing from the ANSI colors, blend the palette red and yellow, and magenta and blue,
+// while gray is the palette muted color, see Palette.MutedColor().
+func (c *Colorable) SetPalette(palette Palette) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.palette = &palette
+
+	return c
+}
+
+// NamedColor returns the color of the given name, as of Red(), and false if it is not a named color.
+func (c *Colorable) NamedColor(name string) (Color, bool) {
+	named, ok := namedColors[name]
+	if !ok {
+		return nil, false
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.palette == nil {
+		return RGB(named.rgb[0], named.rgb[1], named.rgb[2]), true
+	}
+
+	if named.muted {
+		return c.palette.MutedColor(), true
+	}
+
+	var red, green, blue int
+	for _, index := range named.indexes {
+		color := c.palette.Color(index)
+		red, green, blue = red+int(color.Red()), green+int(color.Green()), blue+int(color.Blue())
+	}
+	count := len(named.indexes)
+
+	return RGB(byte(red/count), byte(green/count), byte(blue/count)), true
+}
+
 // Set a Style for the next output operations.
 func (c *Colorable) Set(style Style) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.flushLink(c.output, style.Link)
 	c.setWriter(c.output, style)
 	c.appliedStyle = style
 
@@ -90,31 +192,111 @@ func (c *Colorable) Set(style Style) *Colorable {
 
 // Reset the color value to the default.
 func (c *Colorable) Reset() *Colorable {
-	return c.unsetWriter(c.output, c.appliedStyle)
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.unsetWriter(c.output, c.appliedStyle)
+	c.flushLink(c.output, Link{})
+
+	return c
+}
+
+// Push applies a nested style, inheriting the unset fields from the applied style,
+// e.g. a bold text inside a red block stays red. Pop() restores the outer style.
+func (c *Colorable) Push(style Style) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.outerStyles = append(c.outerStyles, c.appliedStyle)
+	c.appliedStyle = style.Inherit(c.appliedStyle)
+	c.flushLink(c.output, c.appliedStyle.Link)
+
+	return c.setWriter(c.output, c.appliedStyle)
+}
+
+// Pop restores the style that was applied before the last Push(),
+// it has no effect without a matching Push().
+func (c *Colorable) Pop() *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if len(c.outerStyles) == 0 {
+		return c
+	}
+
+	outerStyle := c.outerStyles[len(c.outerStyles)-1]
+	c.outerStyles = c.outerStyles[:len(c.outerStyles)-1]
+
+	c.unsetWriter(c.output, c.appliedStyle)
+	c.flushLink(c.output, outerStyle.Link)
+	c.appliedStyle = outerStyle
+	if !outerStyle.IsZero() {
+		c.setWriter(c.output, outerStyle)
+	}
+
+	return c
+}
+
+// WithStyle calls f with the style pushed, and pops it afterwards even if f panics.
+func (c *Colorable) WithStyle(style Style, f func()) {
+	c.Push(style)
+	defer c.Pop()
+
+	f()
+}
+
+// Write writes p wrapped with the style applied by Set(), which makes the Colorable an io.Writer,
+// e.g. log.New(colorized.Set(style), "", log.LstdFlags).
+// The style is applied again after any reset sequence found in p,
+// and persists for the next writes till another style is set.
+// The sequence written by Set() is not repeated by the first write that follows it,
+// and the link fallback, as in " (url)", is written once the style is reset or replaced, rather than by each write.
+func (c *Colorable) Write(p []byte) (n int, err error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.appliedStyle.IsZero() {
+		return c.output.Write(p)
+	}
+
+	buffer := c.appendStyled(make([]byte, 0, len(p)+2*maxSequenceLength), c.appliedStyle, string(p), true)
+	if c.isStyleOpen {
+		// The style is still active since Set(), so it is not opened again.
+		var openSequence [maxSequenceLength]byte
+		buffer = bytes.TrimPrefix(buffer, c.appendOpen(openSequence[:0], c.appliedStyle))
+	}
+	// The written text ends with the close sequence.
+	c.isStyleOpen = false
+
+	if _, err = c.output.Write(buffer); err != nil {
+		return 0, err
+	}
+
+	if c.hasLinkFallback() && !c.appliedStyle.Link.IsZero() {
+		c.pendingLink = c.appliedStyle.Link
+	}
+
+	return len(p), nil
+}
+
+// WriteString acts as Write(), for a string.
+func (c *Colorable) WriteString(s string) (n int, err error) {
+	return c.Write([]byte(s))
 }
 
 // Fprint acts as the standard fmt.Fprint() method, wrapped with the given style.
 func (c *Colorable) Fprint(w io.Writer, style Style, s ...interface{}) (n int, err error) {
-	c.setWriter(w, style)
-	defer c.unsetWriter(w, style)
-
-	return fmt.Fprint(w, s...)
+	return c.write(w, style, fmt.Sprint(s...))
 }
 
 // Fprintf acts as the standard fmt.Fprintf() method, wrapped with the given style.
 func (c *Colorable) Fprintf(w io.Writer, style Style, format string, s ...interface{}) (n int, err error) {
-	c.setWriter(w, style)
-	defer c.unsetWriter(w, style)
-
-	return fmt.Fprintf(c.output, format, s...)
+	return c.write(w, style, fmt.Sprintf(format, s...))
 }
 
 // Fprintln acts as the standard fmt.Fprintln() method, wrapped with the given style.
 func (c *Colorable) Fprintln(w io.Writer, style Style, s ...interface{}) (n int, err error) {
-	c.setWriter(w, style)
-	defer c.unsetWriter(w, style)
-
-	return fmt.Fprintln(c.output, s...)
+	return c.write(w, style, fmt.Sprintln(s...))
 }
 
 // Print acts as the standard fmt.Print() method, wrapped with the given style.
@@ -147,6 +329,43 @@ func (c *Colorable) Sprintln(style Style, s ...interface{}) string {
 	return c.wrap(style, fmt.Sprintln(s...))
 }
 
+// Append appends s wrapped with the given style to dst, and returns the extended buffer.
+// e.g.: buffer = colorized.Append(buffer[:0], style, "text")
+func (c *Colorable) Append(dst []byte, style Style, s string) []byte {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.appendStyled(dst, style, s, false)
+}
+
+// AppendCompiled acts as Append(), using the prebuilt sequences of the compiled style.
+func (c *Colorable) AppendCompiled(dst []byte, style CompiledStyle, s string) []byte {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if !c.isColorEnabled() {
+		return c.appendClose(append(dst, s...), style.style)
+	}
+
+	return appendLines(dst, s, style.open, style.close, c.lineMode, false)
+}
+
+// Compile freezes the style, degraded to the Colorable profile, into prebuilt sequences.
+func (c *Colorable) Compile(style Style) CompiledStyle {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.profile.apply(style).compile(c.profile.Supports(Hyperlinks))
+}
+
+// Hyperlink returns the styled text as an OSC 8 hyperlink to the url,
+// falling back to "text (url)" when the profile does not support hyperlinks.
+func (c *Colorable) Hyperlink(url, text string, style Style) string {
+	style.Link.URL = url
+
+	return c.Sprint(style, text)
+}
+
 // FprintFunc returns a new callback that prints the passed arguments as Colorable.Fprint().
 func (c *Colorable) FprintFunc() func(w io.Writer, style Style, s ...interface{}) (n int, err error) {
 	return func(w io.Writer, style Style, s ...interface{}) (n int, err error) {
@@ -212,59 +431,60 @@ func (c *Colorable) SprintlnFunc() func(style Style, s ...interface{}) string {
 
 // Black returns a black foreground color effect.
 func (c *Colorable) Black(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(0, 0, 0), s...)
+	return c.Sprint(c.foregroundStyle("black"), s...)
 }
 
 // Blue returns a blue foreground color effect.
 func (c *Colorable) Blue(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(0, 0, 255), s...)
+	return c.Sprint(c.foregroundStyle("blue"), s...)
 }
 
 // Cyan returns a cyan foreground color effect.
 func (c *Colorable) Cyan(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(0, 255, 255), s...)
+	return c.Sprint(c.foregroundStyle("cyan"), s...)
 }
 
 // Gray returns a gray foreground color effect.
 func (c *Colorable) Gray(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(128, 128, 128), s...)
+	return c.Sprint(c.foregroundStyle("gray"), s...)
 }
 
 // Green returns a green foreground color effect.
 func (c *Colorable) Green(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(0, 255, 0), s...)
+	return c.Sprint(c.foregroundStyle("green"), s...)
 }
 
 // Magenta returns a magenta foreground color effect.
 func (c *Colorable) Magenta(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(255, 0, 255), s...)
+	return c.Sprint(c.foregroundStyle("magenta"), s...)
 }
 
 // Orange returns an orange foreground color effect.
 func (c *Colorable) Orange(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(255, 165, 0), s...)
+	return c.Sprint(c.foregroundStyle("orange"), s...)
 }
 
 // Purple returns a purple foreground color effect.
 func (c *Colorable) Purple(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(128, 0, 128), s...)
+	return c.Sprint(c.foregroundStyle("purple"), s...)
 }
 
 // Red returns a red foreground color effect.
 func (c *Colorable) Red(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(255, 0, 0), s...)
+	return c.Sprint(c.foregroundStyle("red"), s...)
 }
 
 // White returns a white foreground color effect.
 func (c *Colorable) White(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(255, 255, 255), s...)
+	return c.Sprint(c.foregroundStyle("white"), s...)
 }
 
 // Yellow returns a yellow foreground color effect.
 func (c *Colorable) Yellow(s ...interface{}) string {
-	return c.Sprint(getForegroundStyle(255, 255, 0), s...)
+	return c.Sprint(c.foregroundStyle("yellow"), s...)
 }
 
+// isColorEnabled and the below helpers are called with c.mux held.
 func (c *Colorable) isColorEnabled() bool {
 	colorDisabledMux.Lock()
 	defer colorDisabledMux.Unlock()
@@ -277,31 +497,102 @@ func (c *Colorable) isColorEnabled() bool {
 }
 
 func (c *Colorable) setWriter(w io.Writer, style Style) *Colorable {
-	if !c.isColorEnabled() {
-		return c
+	sequence := c.appendOpen(make([]byte, 0, maxSequenceLength), style)
+	if len(sequence) > 0 {
+		w.Write(sequence)
 	}
-
-	fmt.Fprint(w, style)
+	c.isStyleOpen = len(sequence) > 0
 
 	return c
 }
 
 func (c *Colorable) unsetWriter(w io.Writer, style Style) *Colorable {
-	if !c.isColorEnabled() {
-		return c
+	if sequence := c.appendWriterClose(make([]byte, 0, maxSequenceLength), style); len(sequence) > 0 {
+		w.Write(sequence)
 	}
-
-	fmt.Fprint(w, style.resetFormat())
+	c.isStyleOpen = false
 
 	return c
 }
 
+// write writes s wrapped with the style as a single Write() call, so the concurrent
+// writes of the same writer do not interleave the escape sequences and the text.
+func (c *Colorable) write(w io.Writer, style Style, s string) (n int, err error) {
+	if _, err = w.Write(c.Append(make([]byte, 0, len(s)+2*maxSequenceLength), style, s)); err != nil {
+		return 0, err
+	}
+
+	return len(s), nil
+}
+
 func (c *Colorable) wrap(style Style, s string) string {
+	return string(c.Append(make([]byte, 0, len(s)+2*maxSequenceLength), style, s))
+}
+
+// appendStyled appends s wrapped with the style, as dictated by the line mode.
+func (c *Colorable) appendStyled(dst []byte, style Style, s string, reapply bool) []byte {
+	// The open sequence is built into dst, as it escapes through the Color interface,
+	// and copied to the stack, so no allocation is made unless dst grows.
+	var openSequence, closeSequence [maxSequenceLength]byte
+	start := len(dst)
+	dst = c.appendOpen(dst, style)
+	open := append(openSequence[:0], dst[start:]...)
+	var close []byte
+	if reapply {
+		close = c.appendWriterClose(closeSequence[:0], style)
+	} else {
+		close = c.appendClose(closeSequence[:0], style)
+	}
+
+	return appendLines(dst[:start], s, open, close, c.lineMode, reapply)
+}
+
+// appendOpen appends the sequences that apply the style, degraded to the Colorable profile.
+func (c *Colorable) appendOpen(dst []byte, style Style) []byte {
 	if !c.isColorEnabled() {
-		return s
+		return dst
+	}
+
+	return c.profile.apply(style).appendOpen(dst, c.profile.Supports(Hyperlinks))
+}
+
+// appendClose appends the sequences that reset the style,
+// or only the link fallback when the color is disabled.
+func (c *Colorable) appendClose(dst []byte, style Style) []byte {
+	if !c.isColorEnabled() {
+		if style.Link.IsZero() {
+			return dst
+		}
+
+		return style.Link.appendFallback(dst)
+	}
+
+	return style.appendClose(dst, c.profile.Supports(Hyperlinks))
+}
+
+// appendWriterClose appends the sequences that reset the style applied by Set(),
+// leaving out the link fallback, written by flushLink() instead.
+func (c *Colorable) appendWriterClose(dst []byte, style Style) []byte {
+	if c.hasLinkFallback() {
+		style.Link = Link{}
 	}
 
-	return style.String() + s + style.resetFormat()
+	return c.appendClose(dst, style)
+}
+
+// hasLinkFallback returns true if the links are written as text, rather than as hyperlinks.
+func (c *Colorable) hasLinkFallback() bool {
+	return !c.isColorEnabled() || !c.profile.Supports(Hyperlinks)
+}
+
+// flushLink writes the fallback of the link written since Set(), unless the next applied style keeps it.
+func (c *Colorable) flushLink(w io.Writer, next Link) {
+	if c.pendingLink.IsZero() || c.pendingLink == next {
+		return
+	}
+
+	w.Write(c.pendingLink.appendFallback(make([]byte, 0, len(c.pendingLink.URL)+3)))
+	c.pendingLink = Link{}
 }
 
 func boolPtr(v bool) *bool {
@@ -311,22 +602,7 @@ func boolPtr(v bool) *bool {
 // getCachedColorValue returns a new/cached Color instance
 // to reduce to amount of the created color objects.
 func getCachedColorValue(red, green, blue, alpha byte) Color {
-	cacheKey := fmt.Sprintf(
-		"%d.%d.%d.%d",
-		red,
-		green,
-		blue,
-		alpha,
-	)
-
-	colorValue, ok := colorCache.Load(cacheKey)
-	if !ok {
-		colorInstance := createColor(red, green, blue, alpha)
-		colorCache.Store(colorInstance.String(), colorInstance)
-		colorValue = colorInstance
-	}
-
-	return colorValue.(Color)
+	return colorCache.get(uint32(red)<<24 | uint32(green)<<16 | uint32(blue)<<8 | uint32(alpha))
 }
 
 // RGB returns a new/cached instance of the Color.
@@ -376,18 +652,11 @@ func createColor(red, green, blue, alpha byte) Color {
 	}
 }
 
-func getForegroundStyle(red, green, blue byte) Style {
-	return Style{
-		Foreground: RGB(red, green, blue),
-	}
-}
+// foregroundStyle returns the style of the named color foreground.
+func (c *Colorable) foregroundStyle(name string) Style {
+	foreground, _ := c.NamedColor(name)
 
-func fontExists(font FontEffect, fonts []FontEffect) bool {
-	for _, fontItem := range fonts {
-		if font == fontItem {
-			return true
-		}
+	return Style{
+		Foreground: foreground,
 	}
-
-	return false
 }
@@ -28,7 +28,7 @@ func BenchmarkColorizeSetReset(b *testing.B) {
 				Style{
 					Foreground: RGB(255, 255, 255),
 					Background: RGB(155, 155, 155),
-					Font:       []FontEffect{Bold, Italic, Underline},
+					Font:       Fonts(Bold, Italic, Underline),
 				},
 				"",
 			)
@@ -39,3 +39,64 @@ func BenchmarkColorizeSetReset(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkColorizeAppend(b *testing.B) {
+	colorized := NewColorable(os.Stdout)
+	style := Style{
+		Foreground: RGB(255, 255, 255),
+		Background: RGB(155, 155, 155),
+		Font:       Fonts(Bold, Italic, Underline),
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		buffer := make([]byte, 0, 256)
+		for pb.Next() {
+			buffer = colorized.Append(buffer[:0], style, "appended string.")
+		}
+	})
+}
+
+func BenchmarkColorizeAppendCompiled(b *testing.B) {
+	colorized := NewColorable(os.Stdout)
+	style := colorized.Compile(Style{
+		Foreground: RGB(255, 255, 255),
+		Background: RGB(155, 155, 155),
+		Font:       Fonts(Bold, Italic, Underline),
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		buffer := make([]byte, 0, 256)
+		for pb.Next() {
+			buffer = colorized.AppendCompiled(buffer[:0], style, "appended string.")
+		}
+	})
+}
+
+func BenchmarkColorizeRGB(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			RGB(255, 165, 0)
+		}
+	})
+}
+
+func BenchmarkColorCache(b *testing.B) {
+	cache := newLRUColorCache(DefaultColorCacheLimit)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		key := uint32(0)
+		for pb.Next() {
+			// Cycles over twice the limit, so the hits are mixed with evictions.
+			cache.get((key % (2 * DefaultColorCacheLimit)) << 8)
+			key++
+		}
+	})
+}
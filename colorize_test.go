@@ -1,17 +1,21 @@
 package colorize
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	baseColor "image/color"
 	"io"
 	"io/ioutil"
+	"log"
 	"os"
+	"sync"
 	"testing"
 )
 
 func TestMain(m *testing.M) {
 	IsColorDisabled = false
+	DefaultProfile = ExtendedProfile
 	defer func() {
 		IsColorDisabled = true
 	}()
@@ -38,6 +42,7 @@ func TestAppliedStyle(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 			assert.Equal(t, Style{}, colorized.appliedStyle)
@@ -70,6 +75,7 @@ func TestDisableEnableColor(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -128,6 +134,7 @@ func TestFprint(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -164,6 +171,7 @@ func TestFprintf(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -200,6 +208,7 @@ func TestFprintln(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -236,6 +245,7 @@ func TestPrint(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -272,6 +282,7 @@ func TestPrintf(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -308,6 +319,7 @@ func TestPrintln(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -345,6 +357,7 @@ func TestSprint(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -377,6 +390,7 @@ func TestSprintf(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -409,6 +423,7 @@ func TestSprintln(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -440,6 +455,7 @@ func TestFprintFunc(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -475,6 +491,7 @@ func TestFprintfFunc(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -510,6 +527,7 @@ func TestFprintlnFunc(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -545,6 +563,7 @@ func TestPrintFunc(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -580,6 +599,7 @@ func TestPrintfFunc(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -616,6 +636,7 @@ func TestPrintlnFunc(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -653,6 +674,7 @@ func TestSprintFunc(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -685,6 +707,7 @@ func TestSprintfFunc(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -717,6 +740,7 @@ func TestSprintlnFunc(t *testing.T) {
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -748,6 +772,7 @@ func TestSet(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -786,6 +811,7 @@ func TestReset(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -825,7 +851,7 @@ func TestColorEffects(t *testing.T) {
 			input: "Output this in bold Blue foreground color.",
 			appliedStyle: Style{
 				Foreground: RGB(0, 255, 0),
-				Font:       []FontEffect{Bold},
+				Font:       Fonts(Bold),
 			},
 			expected: "\x1b[38;2;0;255;0;1mOutput this in bold Blue foreground color.\x1b[0m",
 		},
@@ -834,7 +860,7 @@ func TestColorEffects(t *testing.T) {
 			input: "Output this in bold italic Cyan foreground color.",
 			appliedStyle: Style{
 				Foreground: RGB(0, 255, 255),
-				Font:       []FontEffect{Bold, Italic},
+				Font:       Fonts(Bold, Italic),
 			},
 			expected: "\x1b[38;2;0;255;255;1;3mOutput this in bold italic Cyan foreground color.\x1b[0m",
 		},
@@ -861,14 +887,24 @@ func TestColorEffects(t *testing.T) {
 			appliedStyle: Style{
 				Foreground: RGB(255, 165, 0),
 				Background: RGB(128, 0, 128),
-				Font:       []FontEffect{Underline, CrossedOut},
+				Font:       Fonts(Underline, CrossedOut),
 			},
 			expected: "\x1b[38;2;255;165;0;48;2;128;0;128;4;9mOutput this in underline crossed out Orange foreground and Purple background color.\x1b[0m",
 		},
+		{
+			id:    "Should output in dashed Red underline.",
+			input: "Output this in dashed Red underline.",
+			appliedStyle: Style{
+				UnderlineStyle: DashedUnderline,
+				UnderlineColor: RGB(255, 0, 0),
+			},
+			expected: "\x1b[4:5;58;2;255;0;0mOutput this in dashed Red underline.\x1b[0m",
+		},
 	}
 
 	colorized := NewColorable(os.Stdout)
 	for _, testCase := range testCases {
+		testCase := testCase
 		t.Run(testCase.id, func(t *testing.T) {
 			t.Parallel()
 
@@ -989,6 +1025,299 @@ func TestDirectColors(t *testing.T) {
 	}
 }
 
+func TestDirectColorsPalette(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(nil).EnableColor().SetPalette(DraculaPalette)
+
+	assert.Equal(t, "\x1b[38;2;255;85;85mred\x1b[0m", colorized.Red("red"))
+	assert.Equal(t, "\x1b[38;2;98;114;164mgray\x1b[0m", colorized.Gray("gray"))
+	assert.Equal(t, "\x1b[38;2;248;167;112morange\x1b[0m", colorized.Orange("orange"), "Should blend the red and the yellow.")
+	assert.Equal(t, "\x1b[38;2;255;85;85mred\x1b[0m", colorized.Markup("[red]red[/]"))
+
+	palette, ok := colorized.Palette()
+	assert.True(t, ok)
+	assert.Equal(t, DraculaPalette, palette)
+
+	_, ok = NewColorable(nil).Palette()
+	assert.False(t, ok)
+}
+
+func TestAppend(t *testing.T) {
+	style := Style{
+		Foreground: RGB(255, 0, 0),
+		Font:       Fonts(Bold),
+	}
+	testCases := []struct {
+		id        string
+		colorable *Colorable
+		expected  string
+	}{
+		{
+			id:        "Should append the styled text.",
+			colorable: NewColorable(os.Stdout).EnableColor(),
+			expected:  "log: \x1b[38;2;255;0;0;1mappended\x1b[0m",
+		},
+		{
+			id:        "Should append the plain text when the color is disabled.",
+			colorable: NewColorable(os.Stdout).DisableColor(),
+			expected:  "log: appended",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			buffer := make([]byte, 0, 64)
+			compiled := testCase.colorable.Compile(style)
+
+			assert.Equal(t, testCase.expected, string(testCase.colorable.Append(append(buffer, "log: "...), style, "appended")))
+			assert.Equal(t, testCase.expected, string(testCase.colorable.AppendCompiled(append(buffer, "log: "...), compiled, "appended")))
+			assert.Zero(t, testing.AllocsPerRun(100, func() {
+				buffer = testCase.colorable.Append(buffer[:0], style, "appended")
+				buffer = testCase.colorable.AppendCompiled(buffer[:0], compiled, "appended")
+			}))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	style := Style{
+		Foreground: RGB(255, 0, 0),
+	}
+	testCases := []struct {
+		id           string
+		input        string
+		appliedStyle Style
+		expected     string
+	}{
+		{
+			id:           "Should write as is without an applied style.",
+			input:        "plain text.",
+			appliedStyle: Style{},
+			expected:     "\x1b[mplain text.",
+		},
+		{
+			id:           "Should write wrapped with the applied style, opened once by Set().",
+			input:        "styled text.",
+			appliedStyle: style,
+			expected:     "\x1b[38;2;255;0;0mstyled text.\x1b[0m",
+		},
+		{
+			id:           "Should apply the style again after a foreign reset.",
+			input:        "styled \x1b[1mbold\x1b[0m and \x1b[32mgreen\x1b[m text.",
+			appliedStyle: style,
+			expected:     "\x1b[38;2;255;0;0mstyled \x1b[1mbold\x1b[0m\x1b[38;2;255;0;0m and \x1b[32mgreen\x1b[m\x1b[38;2;255;0;0m text.\x1b[0m",
+		},
+		{
+			id:           "Should apply the style again after a reset combined with other attributes.",
+			input:        "styled \x1b[0;1mbold\x1b[0;0m text.",
+			appliedStyle: style,
+			expected:     "\x1b[38;2;255;0;0mstyled \x1b[0m\x1b[38;2;255;0;0m\x1b[1mbold\x1b[0;0m\x1b[38;2;255;0;0m text.\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := bytes.Buffer{}
+			colorized := NewColorable(&output).EnableColor()
+			colorized.Set(testCase.appliedStyle)
+
+			n, err := colorized.WriteString(testCase.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, len(testCase.input), n)
+			assert.Equal(t, fmt.Sprintf("%q", testCase.expected), fmt.Sprintf("%q", output.String()))
+		})
+	}
+}
+
+func TestWriteLogger(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	colorized := NewColorable(output).EnableColor()
+	logger := log.New(colorized.Set(Style{Font: Fonts(Bold)}), "app: ", 0)
+
+	logger.Print("started")
+	logger.Print("stopped")
+
+	assert.Equal(t, "\x1b[1mapp: started\n\x1b[0m\x1b[1mapp: stopped\n\x1b[0m", output.String())
+}
+
+func TestWriteLinkFallback(t *testing.T) {
+	t.Parallel()
+
+	link := Link{URL: "https://example.com"}
+	testCases := []struct {
+		id       string
+		output   func(output *bytes.Buffer)
+		expected string
+	}{
+		{
+			id: "Should write the link fallback once the style is reset, when the color is disabled.",
+			output: func(output *bytes.Buffer) {
+				colorized := NewColorable(output).DisableColor()
+				logger := log.New(colorized.Set(Style{Link: link}), "", 0)
+				logger.Print("started")
+				logger.Print("stopped")
+				colorized.Reset()
+			},
+			expected: "started\nstopped\n (https://example.com)",
+		},
+		{
+			id: "Should write the link fallback once the style is replaced, without hyperlinks support.",
+			output: func(output *bytes.Buffer) {
+				colorized := NewColorable(output).EnableColor().SetProfile(ExtendedProfile.Without(Hyperlinks))
+				colorized.Set(Style{Foreground: RGB(0, 0, 255), Link: link})
+				fmt.Fprint(colorized, "a")
+				fmt.Fprint(colorized, "b")
+				colorized.Set(Style{Font: Fonts(Bold)})
+				fmt.Fprint(colorized, "c")
+			},
+			expected: "\x1b[38;2;0;0;255ma\x1b[0m\x1b[38;2;0;0;255mb\x1b[0m (https://example.com)\x1b[1mc\x1b[0m",
+		},
+		{
+			id: "Should keep the link fallback till the inheriting styles are popped.",
+			output: func(output *bytes.Buffer) {
+				colorized := NewColorable(output).DisableColor()
+				colorized.Push(Style{Link: link})
+				fmt.Fprint(colorized, "a")
+				colorized.Push(Style{Font: Fonts(Bold)})
+				fmt.Fprint(colorized, "b")
+				colorized.Pop()
+				fmt.Fprint(colorized, "c")
+				colorized.Pop()
+				fmt.Fprint(colorized, "d")
+			},
+			expected: "abc (https://example.com)d",
+		},
+		{
+			id: "Should not write the link fallback without any written text.",
+			output: func(output *bytes.Buffer) {
+				NewColorable(output).DisableColor().Set(Style{Link: link}).Reset()
+			},
+			expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.id, func(t *testing.T) {
+			t.Parallel()
+
+			output := &bytes.Buffer{}
+			testCase.output(output)
+
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	colorized := NewColorable(output).EnableColor()
+	red := Style{Foreground: RGB(255, 0, 0)}
+
+	colorized.Push(red)
+	io.WriteString(output, "red ")
+	colorized.Push(Style{Font: Fonts(Bold)})
+	io.WriteString(output, "bold red")
+	assert.Equal(t, Style{Foreground: RGB(255, 0, 0), Font: Fonts(Bold)}, colorized.AppliedStyle())
+	colorized.Pop()
+	io.WriteString(output, " red")
+	assert.Equal(t, red, colorized.AppliedStyle())
+	colorized.Pop().Pop()
+	io.WriteString(output, " plain")
+
+	assert.Equal(
+		t,
+		fmt.Sprintf("%q", "\x1b[38;2;255;0;0mred \x1b[38;2;255;0;0;1mbold red\x1b[0m\x1b[38;2;255;0;0m red\x1b[0m plain"),
+		fmt.Sprintf("%q", output.String()),
+	)
+	assert.Equal(t, Style{}, colorized.AppliedStyle())
+}
+
+func TestWithStyle(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	colorized := NewColorable(output).EnableColor()
+
+	assert.Panics(t, func() {
+		colorized.WithStyle(Style{Background: RGB(0, 0, 255)}, func() {
+			io.WriteString(output, "blue")
+			panic("failure")
+		})
+	})
+
+	assert.Equal(t, "\x1b[48;2;0;0;255mblue\x1b[0m", output.String())
+	assert.Equal(t, Style{}, colorized.AppliedStyle())
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	const writers = 8
+	output := &recordingWriter{}
+	colorized := NewColorable(output).EnableColor()
+	styles := []Style{
+		{Foreground: RGB(255, 0, 0)},
+		{Background: RGB(0, 255, 0), Font: Fonts(Bold)},
+	}
+
+	var waitGroup sync.WaitGroup
+	for index := 0; index < writers; index++ {
+		waitGroup.Add(1)
+		go func(style Style) {
+			defer waitGroup.Done()
+
+			for iteration := 0; iteration < 100; iteration++ {
+				colorized.Print(style, "text")
+				colorized.Fprintln(output, style, "line")
+				colorized.SetProfile(ExtendedProfile)
+				colorized.Set(style).WriteString("written")
+				colorized.Push(style).Pop()
+				colorized.Sprint(style, "string")
+			}
+		}(styles[index%len(styles)])
+	}
+	waitGroup.Wait()
+
+	expected := map[string]bool{}
+	for _, style := range styles {
+		open := style.String()
+		expected[open+"text\x1b[0m"] = true
+		expected[open+"line\n\x1b[0m"] = true
+		expected[open+"written\x1b[0m"] = true
+		expected["written\x1b[0m"] = true
+		expected[open] = true
+		expected["\x1b[0m"] = true
+	}
+
+	for _, write := range output.writes {
+		assert.True(t, expected[write], "Unexpected write %q.", write)
+	}
+}
+
+// recordingWriter keeps each write call separately.
+type recordingWriter struct {
+	mux    sync.Mutex
+	writes []string
+}
+
+func (rw *recordingWriter) Write(p []byte) (n int, err error) {
+	rw.mux.Lock()
+	defer rw.mux.Unlock()
+
+	rw.writes = append(rw.writes, string(p))
+
+	return len(p), nil
+}
+
 func TestHex(t *testing.T) {
 	testCases := []struct {
 		id            string
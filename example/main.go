@@ -16,12 +16,12 @@ func main() {
 	style := colorize.Style{
 		Foreground: colorize.RGB(218, 44, 128),
 		Background: red,
-		Font: []colorize.FontEffect{
+		Font: colorize.Fonts(
 			colorize.Bold,
 			colorize.Italic,
 			colorize.Underline,
 			colorize.CrossedOut,
-		},
+		),
 	}
 
 	callback := colorized.SprintlnFunc()
@@ -31,7 +31,7 @@ func main() {
 
 	colorized.Set(colorize.Style{
 		Foreground: colorize.RGB(255, 188, 88),
-		Font:       []colorize.FontEffect{colorize.Bold},
+		Font:       colorize.Fonts(colorize.Bold),
 	})
 	print("Output will be styled.\nTill next reset!")
 	colorized.Reset()
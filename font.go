@@ -0,0 +1,216 @@
+package colorize
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+type (
+	// FontEffect value.
+	FontEffect int
+
+	// FontEffects is a set of font effects, stored as a bitmask.
+	// The zero value is an empty set, and sets are comparable with ==.
+	FontEffects uint64
+
+	// fontEffectAttributes describes how an effect is turned off,
+	// and what capability is required to render it.
+	fontEffectAttributes struct {
+		effect     FontEffect
+		off        int
+		capability Capability
+	}
+)
+
+// Font effects.
+// Some effects are not supported on all terminals.
+const (
+	Normal FontEffect = iota
+	Bold
+	Faint
+	Italic
+	Underline
+	BlinkSlow
+	BlinkRapid
+	ReverseVideo
+	Concealed
+	CrossedOut
+)
+
+// Alternative fonts, reverted to the primary font by SGR 10.
+const (
+	AlternateFont1 FontEffect = iota + 11
+	AlternateFont2
+	AlternateFont3
+	AlternateFont4
+	AlternateFont5
+	AlternateFont6
+	AlternateFont7
+	AlternateFont8
+	AlternateFont9
+)
+
+// Rarely supported font effects.
+// Terminals without the matching capability drop them, see Profile.
+const (
+	// DoublyUnderlined is treated as "bold off" by some older terminals.
+	DoublyUnderlined FontEffect = 21
+	Framed           FontEffect = 51
+	Encircled        FontEffect = 52
+	Overlined        FontEffect = 53
+	// IdeogramUnderline or right side line.
+	IdeogramUnderline FontEffect = 60
+	// IdeogramDoubleUnderline or double line on the right side.
+	IdeogramDoubleUnderline FontEffect = 61
+	// IdeogramOverline or left side line.
+	IdeogramOverline FontEffect = 62
+	// IdeogramDoubleOverline or double line on the left side.
+	IdeogramDoubleOverline FontEffect = 63
+	IdeogramStressMarking  FontEffect = 64
+	Superscript            FontEffect = 73
+	Subscript              FontEffect = 74
+)
+
+// fontEffects lists the known font effects in their SGR order,
+// the index of each effect is its bit in the FontEffects set.
+var fontEffects = [...]fontEffectAttributes{
+	{effect: Normal, off: 0},
+	{effect: Bold, off: 22},
+	{effect: Faint, off: 22},
+	{effect: Italic, off: 23},
+	{effect: Underline, off: 24},
+	{effect: BlinkSlow, off: 25},
+	{effect: BlinkRapid, off: 25},
+	{effect: ReverseVideo, off: 27},
+	{effect: Concealed, off: 28},
+	{effect: CrossedOut, off: 29},
+	{effect: AlternateFont1, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont2, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont3, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont4, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont5, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont6, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont7, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont8, off: 10, capability: AlternateFonts},
+	{effect: AlternateFont9, off: 10, capability: AlternateFonts},
+	{effect: DoublyUnderlined, off: 24, capability: DoublyUnderline},
+	{effect: Framed, off: 54, capability: FramedText},
+	{effect: Encircled, off: 54, capability: FramedText},
+	{effect: Overlined, off: 55, capability: OverlinedText},
+	{effect: IdeogramUnderline, off: 65, capability: IdeogramText},
+	{effect: IdeogramDoubleUnderline, off: 65, capability: IdeogramText},
+	{effect: IdeogramOverline, off: 65, capability: IdeogramText},
+	{effect: IdeogramDoubleOverline, off: 65, capability: IdeogramText},
+	{effect: IdeogramStressMarking, off: 65, capability: IdeogramText},
+	{effect: Superscript, off: 75, capability: ScriptText},
+	{effect: Subscript, off: 75, capability: ScriptText},
+}
+
+// fontEffectBits maps an SGR code to its index in fontEffects, -1 when unknown.
+var fontEffectBits = func() (indexes [Subscript + 1]int8) {
+	for code := range indexes {
+		indexes[code] = -1
+	}
+
+	for index, attributes := range fontEffects {
+		indexes[attributes.effect] = int8(index)
+	}
+
+	return indexes
+}()
+
+// OffCode returns the SGR code that turns the effect off, e.g. 22 for Bold.
+// Unknown effects are turned off by a full reset.
+func (f FontEffect) OffCode() int {
+	index, ok := f.index()
+	if !ok {
+		return 0
+	}
+
+	return fontEffects[index].off
+}
+
+// Capability returns the capability a terminal needs to render the effect,
+// zero for the basic effects that are expected to be always supported.
+func (f FontEffect) Capability() Capability {
+	index, ok := f.index()
+	if !ok {
+		return 0
+	}
+
+	return fontEffects[index].capability
+}
+
+// index returns the position of the effect in fontEffects.
+func (f FontEffect) index() (int, bool) {
+	if f < 0 || int(f) >= len(fontEffectBits) || fontEffectBits[f] < 0 {
+		return 0, false
+	}
+
+	return int(fontEffectBits[f]), true
+}
+
+// Fonts returns a set of the given font effects, e.g. Fonts(Bold, Italic),
+// or Fonts(effects...) for an existing slice. Unknown effects are ignored.
+func Fonts(effects ...FontEffect) FontEffects {
+	return FontEffects(0).Set(effects...)
+}
+
+// Set returns a copy of the set including the given effects.
+func (f FontEffects) Set(effects ...FontEffect) FontEffects {
+	for _, effect := range effects {
+		if index, ok := effect.index(); ok {
+			f |= 1 << uint(index)
+		}
+	}
+
+	return f
+}
+
+// Clear returns a copy of the set excluding the given effects.
+func (f FontEffects) Clear(effects ...FontEffect) FontEffects {
+	for _, effect := range effects {
+		if index, ok := effect.index(); ok {
+			f &^= 1 << uint(index)
+		}
+	}
+
+	return f
+}
+
+// Has returns true if the effect is part of the set.
+func (f FontEffects) Has(effect FontEffect) bool {
+	index, ok := effect.index()
+
+	return ok && f&(1<<uint(index)) != 0
+}
+
+// Len returns the count of effects in the set.
+func (f FontEffects) Len() int {
+	return bits.OnesCount64(uint64(f))
+}
+
+// Effects returns the effects of the set, ordered by their SGR codes, nil for an empty set.
+func (f FontEffects) Effects() []FontEffect {
+	if f == 0 {
+		return nil
+	}
+
+	effects := make([]FontEffect, 0, f.Len())
+	for remaining := f; remaining != 0; remaining &= remaining - 1 {
+		effects = append(effects, fontEffects[bits.TrailingZeros64(uint64(remaining))].effect)
+	}
+
+	return effects
+}
+
+// appendParameters appends the SGR codes of the set to the parameters list,
+// starting at the given offset of the buffer.
+func (f FontEffects) appendParameters(parameters []byte, offset int) []byte {
+	for remaining := f; remaining != 0; remaining &= remaining - 1 {
+		parameters = appendSeparator(parameters, offset)
+		parameters = strconv.AppendInt(parameters, int64(fontEffects[bits.TrailingZeros64(uint64(remaining))].effect), 10)
+	}
+
+	return parameters
+}
@@ -0,0 +1,54 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestFontGlyphs(t *testing.T) {
+	t.Parallel()
+
+	drawing := regexp.MustCompile(`^[.#]{5}( [.#]{5}){6}$`)
+
+	for char := ' '; char <= '~'; char++ {
+		assert.Regexp(t, drawing, fontGlyphs[char], "glyph of %q", char)
+	}
+}
+
+func TestGlyph(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id         string
+		char       rune
+		expected   glyphBitmap
+		expectedOK bool
+	}{
+		{
+			id:         "Should return the bitmap of a printable character.",
+			char:       'T',
+			expected:   glyphBitmap{0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+			expectedOK: true,
+		},
+		{
+			id:         "Should return false for the control characters.",
+			char:       '\t',
+			expectedOK: false,
+		},
+		{
+			id:         "Should return false for the characters missing from the font.",
+			char:       '日',
+			expectedOK: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			bitmap, ok := glyph(testCase.char)
+
+			assert.Equal(t, testCase.expectedOK, ok)
+			assert.Equal(t, testCase.expected, bitmap)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFontEffectOffCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    FontEffect
+		expected int
+	}{
+		{
+			id:       "Should turn bold off by normal intensity.",
+			input:    Bold,
+			expected: 22,
+		},
+		{
+			id:       "Should turn crossed out off by not crossed out.",
+			input:    CrossedOut,
+			expected: 29,
+		},
+		{
+			id:       "Should turn doubly underlined off by not underlined.",
+			input:    DoublyUnderlined,
+			expected: 24,
+		},
+		{
+			id:       "Should turn encircled off by neither framed nor encircled.",
+			input:    Encircled,
+			expected: 54,
+		},
+		{
+			id:       "Should turn overlined off by not overlined.",
+			input:    Overlined,
+			expected: 55,
+		},
+		{
+			id:       "Should turn ideogram stress marking off by no ideogram attributes.",
+			input:    IdeogramStressMarking,
+			expected: 65,
+		},
+		{
+			id:       "Should turn subscript off by neither superscript nor subscript.",
+			input:    Subscript,
+			expected: 75,
+		},
+		{
+			id:       "Should turn alternate fonts off by the primary font.",
+			input:    AlternateFont9,
+			expected: 10,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.input.OffCode())
+		})
+	}
+}
+
+func TestFontEffectCapability(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    FontEffect
+		expected Capability
+	}{
+		{
+			id:       "Should not require a capability for the basic effects.",
+			input:    Italic,
+			expected: 0,
+		},
+		{
+			id:       "Should require alternate fonts capability.",
+			input:    AlternateFont1,
+			expected: AlternateFonts,
+		},
+		{
+			id:       "Should require framed text capability.",
+			input:    Framed,
+			expected: FramedText,
+		},
+		{
+			id:       "Should require ideogram text capability.",
+			input:    IdeogramUnderline,
+			expected: IdeogramText,
+		},
+		{
+			id:       "Should require script text capability.",
+			input:    Superscript,
+			expected: ScriptText,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.input.Capability())
+		})
+	}
+}
+
+func TestFontEffects(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    FontEffects
+		expected []FontEffect
+	}{
+		{
+			id:       "Should be nil for the zero value.",
+			input:    FontEffects(0),
+			expected: nil,
+		},
+		{
+			id:       "Should order the effects by their SGR codes.",
+			input:    Fonts(Subscript, Italic, Bold),
+			expected: []FontEffect{Bold, Italic, Subscript},
+		},
+		{
+			id:       "Should keep a single instance of the duplicated effects.",
+			input:    Fonts(Bold, Bold),
+			expected: []FontEffect{Bold},
+		},
+		{
+			id:       "Should ignore the unknown effects.",
+			input:    Fonts(FontEffect(20), FontEffect(-1), FontEffect(1000), Faint),
+			expected: []FontEffect{Faint},
+		},
+		{
+			id:       "Should include the set effects.",
+			input:    Fonts(Bold).Set(Overlined, AlternateFont3),
+			expected: []FontEffect{Bold, AlternateFont3, Overlined},
+		},
+		{
+			id:       "Should exclude the cleared effects.",
+			input:    Fonts(Bold, Italic, Underline).Clear(Italic, Framed),
+			expected: []FontEffect{Bold, Underline},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.input.Effects())
+			assert.Equal(t, len(testCase.expected), testCase.input.Len())
+
+			for _, effect := range testCase.expected {
+				assert.True(t, testCase.input.Has(effect))
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package colorize
+
+import (
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type (
+	// HTMLOptions of the HTML rendering.
+	HTMLOptions struct {
+		// Classes renders the styles as CSS classes instead of inline styles, see HTMLRenderer.Stylesheet().
+		Classes bool
+		// ClassPrefix of the generated classes, defaults to "ansi-".
+		ClassPrefix string
+		// Palette resolving the indexed colors, the zero value falls back to the xterm colors.
+		Palette Palette
+		// Foreground and Background replace the default colors of the reversed styles,
+		// defaults to the palette foreground and background.
+		Foreground Color
+		Background Color
+		// LinkSchemes of the links rendered as anchors, defaults to DefaultLinkSchemes,
+		// the links of the other schemes, as javascript:, are rendered as plain text.
+		LinkSchemes []string
+	}
+
+	// HTMLRenderer converts styled text into HTML spans, keeping the style between the consecutive Render() calls,
+	// e.g. to convert a build log line by line.
+	HTMLRenderer struct {
+		options HTMLOptions
+		parser  *Parser
+		// classes by the CSS declarations they are generated for.
+		classes map[string]string
+		// declarations of the generated classes, in their generation order.
+		declarations []string
+	}
+)
+
+const defaultClassPrefix = "ansi-"
+
+// DefaultLinkSchemes are the schemes of the links rendered as anchors, unless set by HTMLOptions.LinkSchemes.
+var DefaultLinkSchemes = []string{"http", "https", "mailto"}
+
+// HTML converts the styled text into HTML spans with inline styles.
+// e.g.: HTML("\x1b[1mbold\x1b[0m") -> <span style="font-weight:bold">bold</span>
+func HTML(s string) string {
+	return NewHTMLRenderer(HTMLOptions{}).Render(s)
+}
+
+// NewHTMLRenderer allocates and returns a new HTMLRenderer.
+func NewHTMLRenderer(options HTMLOptions) *HTMLRenderer {
+	if options.ClassPrefix == "" {
+		options.ClassPrefix = defaultClassPrefix
+	}
+
+	if options.LinkSchemes == nil {
+		options.LinkSchemes = DefaultLinkSchemes
+	}
+
+	return &HTMLRenderer{
+		options: options,
+		parser:  NewParser(options.Palette),
+		classes: make(map[string]string),
+	}
+}
+
+// Render converts the styled text into HTML, the text is escaped, and the links become anchors.
+// The new lines are kept, so the output is meant to be placed inside a <pre> element.
+func (r *HTMLRenderer) Render(s string) string {
+	var builder strings.Builder
+
+	for _, segment := range r.parser.Parse(s) {
+		r.writeSegment(&builder, segment)
+	}
+
+	return builder.String()
+}
+
+// Stylesheet returns the CSS rules of the classes generated so far.
+func (r *HTMLRenderer) Stylesheet() string {
+	var builder strings.Builder
+
+	for _, declarations := range r.declarations {
+		builder.WriteString(".")
+		builder.WriteString(r.classes[declarations])
+		builder.WriteString(" { ")
+		builder.WriteString(declarations)
+		builder.WriteString(" }\n")
+	}
+
+	return builder.String()
+}
+
+func (r *HTMLRenderer) writeSegment(builder *strings.Builder, segment Segment) {
+	text := html.EscapeString(segment.Text)
+	style := segment.Style
+	style.Link = Link{}
+
+	if !segment.Style.Link.IsZero() && r.isAllowedLink(segment.Style.Link.URL) {
+		builder.WriteString(`<a href="`)
+		builder.WriteString(html.EscapeString(segment.Style.Link.URL))
+		builder.WriteString(`">`)
+		defer builder.WriteString("</a>")
+	}
+
+	declarations := r.styleDeclarations(style)
+	if declarations == "" {
+		builder.WriteString(text)
+
+		return
+	}
+
+	if r.options.Classes {
+		builder.WriteString(`<span class="`)
+		builder.WriteString(r.class(declarations))
+	} else {
+		builder.WriteString(`<span style="`)
+		builder.WriteString(declarations)
+	}
+	builder.WriteString(`">`)
+	builder.WriteString(text)
+	builder.WriteString("</span>")
+}
+
+// isAllowedLink returns true if the url is absolute, and its scheme is one of the allowed link schemes.
+func (r *HTMLRenderer) isAllowedLink(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for _, scheme := range r.options.LinkSchemes {
+		if parsed.Scheme != "" && strings.EqualFold(parsed.Scheme, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// class returns the class of the declarations, generating it on the first use,
+// so the styles rendered alike share their class.
+func (r *HTMLRenderer) class(declarations string) string {
+	if class, ok := r.classes[declarations]; ok {
+		return class
+	}
+
+	class := r.options.ClassPrefix + strconv.Itoa(len(r.declarations)+1)
+	r.classes[declarations] = class
+	r.declarations = append(r.declarations, declarations)
+
+	return class
+}
+
+// styleDeclarations returns the CSS declarations of the style, separated by ";".
+func (r *HTMLRenderer) styleDeclarations(style Style) string {
+	foreground, background := style.Foreground, style.Background
+	if style.Font.Has(ReverseVideo) {
+		foreground, background = r.defaultColor(background, r.options.Background, r.options.Palette.DefaultBackground()),
+			r.defaultColor(foreground, r.options.Foreground, r.options.Palette.DefaultForeground())
+	}
+
+	declarations := make([]string, 0, 8)
+	if foreground != nil {
+		declarations = append(declarations, "color:"+foreground.Hex())
+	}
+	if background != nil {
+		declarations = append(declarations, "background-color:"+background.Hex())
+	}
+
+	switch {
+	case style.Font.Has(Bold):
+		declarations = append(declarations, "font-weight:bold")
+	case style.Font.Has(Faint):
+		declarations = append(declarations, "opacity:0.7")
+	}
+	if style.Font.Has(Italic) {
+		declarations = append(declarations, "font-style:italic")
+	}
+	if style.Font.Has(Concealed) {
+		declarations = append(declarations, "visibility:hidden")
+	}
+
+	return strings.Join(append(declarations, textDecorations(style)...), ";")
+}
+
+// defaultColor returns the color, falling back to the given default, then to the palette color.
+func (r *HTMLRenderer) defaultColor(color, defaultColor, paletteColor Color) Color {
+	if color != nil {
+		return color
+	}
+
+	if defaultColor != nil {
+		return defaultColor
+	}
+
+	return paletteColor
+}
+
+// textDecorations returns the CSS declarations of the underline, overline and strike effects.
+func textDecorations(style Style) []string {
+	lines := textDecorationLines(style)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	declarations := []string{"text-decoration-line:" + strings.Join(lines, " ")}
+	underlineStyle, ok := underlineStyles[style.UnderlineStyle]
+	switch {
+	case ok:
+		declarations = append(declarations, "text-decoration-style:"+underlineStyle)
+	case style.Font.Has(DoublyUnderlined):
+		declarations = append(declarations, "text-decoration-style:double")
+	}
+	if style.UnderlineColor != nil {
+		declarations = append(declarations, "text-decoration-color:"+style.UnderlineColor.Hex())
+	}
+
+	return declarations
+}
+
+// textDecorationLines returns the underline, overline and line-through decorations of the style.
+func textDecorationLines(style Style) []string {
+	lines := make([]string, 0, 3)
+	if style.Font.Has(Underline) || style.Font.Has(DoublyUnderlined) || style.UnderlineStyle != NoUnderline {
+		lines = append(lines, "underline")
+	}
+	if style.Font.Has(Overlined) {
+		lines = append(lines, "overline")
+	}
+	if style.Font.Has(CrossedOut) {
+		lines = append(lines, "line-through")
+	}
+
+	return lines
+}
+
+// underlineStyles maps the underline styles to their CSS text-decoration-style.
+var underlineStyles = map[UnderlineStyle]string{
+	DoubleUnderline: "double",
+	CurlyUnderline:  "wavy",
+	DottedUnderline: "dotted",
+	DashedUnderline: "dashed",
+}
@@ -0,0 +1,103 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected string
+	}{
+		{
+			id:       "Should escape the plain text.",
+			input:    "<a & b>",
+			expected: "&lt;a &amp; b&gt;",
+		},
+		{
+			id:       "Should render the colors and the font effects as inline styles.",
+			input:    "\x1b[38;2;255;0;0;48;5;21;1;3mred\x1b[0m plain",
+			expected: `<span style="color:#ff0000;background-color:#0000ff;font-weight:bold;font-style:italic">red</span> plain`,
+		},
+		{
+			id:    "Should render the text decorations.",
+			input: "\x1b[4:3;58;2;0;255;0;9mcurly\x1b[0m\x1b[21;53mdouble",
+			expected: `<span style="text-decoration-line:underline line-through;text-decoration-style:wavy;text-decoration-color:#00ff00">curly</span>` +
+				`<span style="text-decoration-line:underline overline;text-decoration-style:double">double</span>`,
+		},
+		{
+			id:       "Should swap the colors of the reversed text, falling back to the palette.",
+			input:    "\x1b[7;31mreversed\x1b[0m",
+			expected: `<span style="color:#000000;background-color:#cd0000">reversed</span>`,
+		},
+		{
+			id:       "Should hide the concealed text.",
+			input:    "\x1b[8msecret",
+			expected: `<span style="visibility:hidden">secret</span>`,
+		},
+		{
+			id:       "Should render the hyperlinks as anchors.",
+			input:    "\x1b]8;;https://example.com/?a=1&b=2\x1b\\\x1b[1mlink\x1b[0m\x1b]8;;\x1b\\",
+			expected: `<a href="https://example.com/?a=1&amp;b=2"><span style="font-weight:bold">link</span></a>`,
+		},
+		{
+			id:       "Should render the links of the other schemes as plain text.",
+			input:    "\x1b]8;;javascript:alert(1)\x1b\\click\x1b]8;;\x1b\\ \x1b]8;;JavaScript:alert(1)\aagain\x1b]8;;\a \x1b]8;;/relative\aplain\x1b]8;;\a",
+			expected: `click again plain`,
+		},
+		{
+			id:       "Should render the mail links as anchors.",
+			input:    "\x1b]8;;mailto:dev@example.com\x1b\\mail\x1b]8;;\x1b\\",
+			expected: `<a href="mailto:dev@example.com">mail</a>`,
+		},
+		{
+			id:       "Should render an underline of an unknown style as a plain one.",
+			input:    "\x1b[4:9munderlined",
+			expected: `<span style="text-decoration-line:underline">underlined</span>`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, HTML(testCase.input))
+		})
+	}
+}
+
+func TestHTMLRendererLinkSchemes(t *testing.T) {
+	t.Parallel()
+
+	renderer := NewHTMLRenderer(HTMLOptions{LinkSchemes: []string{"file"}})
+
+	assert.Equal(
+		t,
+		`<a href="file:///var/log/build.log">log</a> site`,
+		renderer.Render("\x1b]8;;file:///var/log/build.log\alog\x1b]8;;\a \x1b]8;;https://example.com\asite\x1b]8;;\a"),
+	)
+}
+
+func TestHTMLRendererClasses(t *testing.T) {
+	t.Parallel()
+
+	renderer := NewHTMLRenderer(HTMLOptions{Classes: true, ClassPrefix: "log-"})
+
+	assert.Equal(
+		t,
+		`<span class="log-1">bold</span> <span class="log-2">red</span>`,
+		renderer.Render("\x1b[1mbold\x1b[0m \x1b[31mred"),
+	)
+	assert.Equal(
+		t,
+		`<span class="log-2">still red</span><span class="log-1">bold</span>`,
+		renderer.Render("still red\x1b[0;1mbold"),
+	)
+	assert.Equal(
+		t,
+		".log-1 { font-weight:bold }\n.log-2 { color:#cd0000 }\n",
+		renderer.Stylesheet(),
+	)
+}
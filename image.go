@@ -0,0 +1,202 @@
+package colorize
+
+import (
+	"image"
+	baseColor "image/color"
+	"image/draw"
+	"unicode/utf8"
+)
+
+type (
+	// ImageOptions of the raster rendering, the zero value renders with the defaults.
+	ImageOptions struct {
+		// Columns of the terminal, wrapping the longer rows, defaults to the widest row.
+		Columns int
+		// Scale of the font pixels, defaults to 2, as the cells are 6x10 pixels on scale 1.
+		Scale int
+		// Padding around the cells in pixels, defaults to 8.
+		Padding int
+		// Palette resolving the indexed colors, the zero value falls back to the xterm colors.
+		Palette Palette
+		// Foreground and Background of the unstyled text, defaults to the palette foreground and background.
+		Foreground Color
+		Background Color
+	}
+
+	// imageRenderer draws a screen into an image.
+	imageRenderer struct {
+		options    ImageOptions
+		image      *image.RGBA
+		cellWidth  int
+		cellHeight int
+	}
+)
+
+// Cell layout on scale 1, the glyph is drawn below the glyph top, followed by the underline.
+const (
+	imageCellWidth  = 6
+	imageCellHeight = 10
+	glyphTop        = 1
+	overlineRow     = 0
+	strikeRow       = 4
+	underlineRow    = 8
+	// italicRows of the glyph top shifted to the right.
+	italicRows          = 3
+	defaultImageScale   = 2
+	defaultImagePadding = 8
+)
+
+// RenderImage rasterizes the styled text with the bundled bitmap font, e.g. to encode it by image/png or image/gif.
+// The characters missing from the font are drawn as boxes.
+// e.g.: png.Encode(file, RenderImage(colorized.Sprint(style, "text"), ImageOptions{}))
+func RenderImage(input string, options ImageOptions) *image.RGBA {
+	r := newImageRenderer(options)
+	r.render(newScreen(NewParser(r.options.Palette).Parse(input), r.options.Columns))
+
+	return r.image
+}
+
+func newImageRenderer(options ImageOptions) *imageRenderer {
+	if options.Scale <= 0 {
+		options.Scale = defaultImageScale
+	}
+	if options.Padding <= 0 {
+		options.Padding = defaultImagePadding
+	}
+	if options.Foreground == nil {
+		options.Foreground = options.Palette.DefaultForeground()
+	}
+	if options.Background == nil {
+		options.Background = options.Palette.DefaultBackground()
+	}
+
+	return &imageRenderer{
+		options:    options,
+		cellWidth:  imageCellWidth * options.Scale,
+		cellHeight: imageCellHeight * options.Scale,
+	}
+}
+
+func (r *imageRenderer) render(sc screen) {
+	width := 2*r.options.Padding + sc.columns*r.cellWidth
+	height := 2*r.options.Padding + len(sc.rows)*r.cellHeight
+
+	r.image = image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(r.image, r.image.Bounds(), image.NewUniform(toRGBA(r.options.Background)), image.Point{}, draw.Src)
+
+	for row, runs := range sc.rows {
+		for _, run := range runs {
+			r.drawRun(row, run)
+		}
+	}
+}
+
+func (r *imageRenderer) drawRun(row int, run cellRun) {
+	foreground, background := resolveColors(run.style, r.options.Foreground, r.options.Background)
+	x := r.options.Padding + run.column*r.cellWidth
+	y := r.options.Padding + row*r.cellHeight
+
+	if !background.Equals(r.options.Background) {
+		r.fill(image.Rect(x, y, x+run.width*r.cellWidth, y+r.cellHeight), toRGBA(background))
+	}
+
+	if run.style.Font.Has(Concealed) {
+		return
+	}
+
+	color := toRGBA(foreground)
+	if run.style.Font.Has(Faint) {
+		color = blend(color, toRGBA(background))
+	}
+
+	for text, column := []byte(run.text), 0; len(text) > 0; {
+		size, width := nextCluster(text)
+		r.drawGlyph(x+column*r.cellWidth, y, text[:size], width, run.style, color)
+		text, column = text[size:], column+width
+	}
+
+	lines := make([]int, 0, 3)
+	if run.style.Font.Has(Underline) || run.style.Font.Has(DoublyUnderlined) || run.style.UnderlineStyle != NoUnderline {
+		lines = append(lines, underlineRow)
+	}
+	if run.style.Font.Has(Overlined) {
+		lines = append(lines, overlineRow)
+	}
+	if run.style.Font.Has(CrossedOut) {
+		lines = append(lines, strikeRow)
+	}
+
+	for _, line := range lines {
+		lineColor := color
+		if line == underlineRow && run.style.UnderlineColor != nil {
+			lineColor = toRGBA(run.style.UnderlineColor)
+		}
+		top := y + line*r.options.Scale
+		r.fill(image.Rect(x, top, x+run.width*r.cellWidth, top+r.options.Scale), lineColor)
+	}
+}
+
+// drawGlyph draws the glyph of the cluster's first character, or a box if the font has none.
+func (r *imageRenderer) drawGlyph(x, y int, cluster []byte, width int, style Style, color baseColor.RGBA) {
+	char, _ := utf8.DecodeRune(cluster)
+	if char == ' ' {
+		return
+	}
+
+	bitmap, ok := glyph(char)
+	if !ok {
+		scale := r.options.Scale
+		box := image.Rect(x, y+glyphTop*scale, x+(width*imageCellWidth-1)*scale, y+(glyphTop+glyphHeight)*scale)
+		r.fill(image.Rect(box.Min.X, box.Min.Y, box.Max.X, box.Min.Y+scale), color)
+		r.fill(image.Rect(box.Min.X, box.Max.Y-scale, box.Max.X, box.Max.Y), color)
+		r.fill(image.Rect(box.Min.X, box.Min.Y, box.Min.X+scale, box.Max.Y), color)
+		r.fill(image.Rect(box.Max.X-scale, box.Min.Y, box.Max.X, box.Max.Y), color)
+
+		return
+	}
+
+	for row, pixels := range bitmap {
+		shift := 0
+		if style.Font.Has(Italic) && row < italicRows {
+			shift = 1
+		}
+
+		for column := 0; column < glyphWidth; column++ {
+			if pixels&(1<<uint(glyphWidth-1-column)) == 0 {
+				continue
+			}
+
+			r.fillPixel(x, y, column+shift, glyphTop+row, color)
+			if style.Font.Has(Bold) {
+				r.fillPixel(x, y, column+shift+1, glyphTop+row, color)
+			}
+		}
+	}
+}
+
+// fillPixel fills a font pixel of the cell at x and y.
+func (r *imageRenderer) fillPixel(x, y, column, row int, color baseColor.RGBA) {
+	scale := r.options.Scale
+	left, top := x+column*scale, y+row*scale
+
+	r.fill(image.Rect(left, top, left+scale, top+scale), color)
+}
+
+func (r *imageRenderer) fill(rectangle image.Rectangle, color baseColor.RGBA) {
+	draw.Draw(r.image, rectangle, image.NewUniform(color), image.Point{}, draw.Src)
+}
+
+// toRGBA converts the color to an opaque image color.
+func toRGBA(color Color) baseColor.RGBA {
+	return baseColor.RGBA{R: color.Red(), G: color.Green(), B: color.Blue(), A: 0xff}
+}
+
+// blend mixes the two colors equally, as for the faint text.
+func blend(color, other baseColor.RGBA) baseColor.RGBA {
+	return baseColor.RGBA{
+		R: byte((int(color.R) + int(other.R)) / 2),
+		G: byte((int(color.G) + int(other.G)) / 2),
+		B: byte((int(color.B) + int(other.B)) / 2),
+		A: 0xff,
+	}
+}
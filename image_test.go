@@ -0,0 +1,106 @@
+package colorize
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"image"
+	baseColor "image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRenderImage(t *testing.T) {
+	t.Parallel()
+
+	black := baseColor.RGBA{A: 0xff}
+	white := baseColor.RGBA{R: 0xe5, G: 0xe5, B: 0xe5, A: 0xff}
+	red := baseColor.RGBA{R: 0xcd, A: 0xff}
+	blue := baseColor.RGBA{B: 0xee, A: 0xff}
+
+	// Scale 1, padding 1: the cell of the column c and the row r starts at 1+6c, 1+10r.
+	rendered := RenderImage("\x1b[31;44mT\x1b[0m\x1b[4m_\x1b[0m\n日", ImageOptions{Scale: 1, Padding: 1})
+
+	testCases := []struct {
+		id       string
+		point    image.Point
+		expected baseColor.RGBA
+	}{
+		{
+			id:       "Should fill the padding with the background.",
+			point:    image.Pt(0, 0),
+			expected: black,
+		},
+		{
+			id:       "Should fill the styled cell background.",
+			point:    image.Pt(1, 1),
+			expected: blue,
+		},
+		{
+			id:       "Should draw the glyph pixels with the foreground.",
+			point:    image.Pt(1+2, 1+glyphTop+3),
+			expected: red,
+		},
+		{
+			id:       "Should keep the cell background between the glyph pixels.",
+			point:    image.Pt(1, 1+glyphTop+3),
+			expected: blue,
+		},
+		{
+			id:       "Should draw the underline.",
+			point:    image.Pt(1+6+5, 1+underlineRow),
+			expected: white,
+		},
+		{
+			id:       "Should draw a box for the characters missing from the font.",
+			point:    image.Pt(1+10, 1+10+glyphTop+3),
+			expected: white,
+		},
+		{
+			id:       "Should keep the box empty.",
+			point:    image.Pt(1+5, 1+10+glyphTop+3),
+			expected: black,
+		},
+	}
+
+	assert.Equal(t, image.Rect(0, 0, 2+2*6, 2+2*10), rendered.Bounds())
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, rendered.RGBAAt(testCase.point.X, testCase.point.Y))
+		})
+	}
+}
+
+func TestRenderImageEffects(t *testing.T) {
+	t.Parallel()
+
+	options := ImageOptions{Scale: 1, Padding: 1, Foreground: RGB(0xff, 0xff, 0xff), Background: RGB(0, 0, 0)}
+	gray := baseColor.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}
+	white := baseColor.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	faint := RenderImage("\x1b[2m|", options)
+	assert.Equal(t, gray, faint.RGBAAt(1+2, 1+glyphTop))
+
+	bold := RenderImage("\x1b[1m|", options)
+	assert.Equal(t, white, bold.RGBAAt(1+3, 1+glyphTop))
+
+	italic := RenderImage("\x1b[3m|", options)
+	assert.Equal(t, white, italic.RGBAAt(1+3, 1+glyphTop))
+	assert.Equal(t, white, italic.RGBAAt(1+2, 1+glyphTop+italicRows))
+
+	concealed := RenderImage("\x1b[8m|", options)
+	assert.Equal(t, baseColor.RGBA{A: 0xff}, concealed.RGBAAt(1+2, 1+glyphTop))
+}
+
+func TestRenderImageEncode(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+
+	assert.NoError(t, png.Encode(output, RenderImage(NewColorable(nil).EnableColor().Sprint(Style{Font: Fonts(Bold)}, "ok"), ImageOptions{})))
+
+	decoded, err := png.Decode(output)
+
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2*8+2*6*2, 2*8+10*2), decoded.Bounds())
+}
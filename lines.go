@@ -0,0 +1,69 @@
+package colorize
+
+import (
+	"strings"
+)
+
+type (
+	// LineMode dictates how a styled text spans over multiple lines.
+	LineMode byte
+)
+
+// Line modes.
+const (
+	// SpanLines keeps the style applied across the new lines.
+	SpanLines LineMode = iota
+	// ResetLines resets the style before each new line, and applies it again after,
+	// so pagers and line prefixing tools do not bleed the style.
+	ResetLines
+	// FillLines acts as ResetLines, filling the background till the end of each line.
+	FillLines
+)
+
+// eraseLineSequence erases till the end of the line, using the applied background.
+const eraseLineSequence = "\x1b[K"
+
+// appendLines appends s wrapped with the open and close sequences, as dictated by the line mode.
+// A reset sequence found in s is followed by the open sequence, if reapply is true.
+func appendLines(dst []byte, s string, open, close []byte, mode LineMode, reapply bool) []byte {
+	if mode == SpanLines || len(open) == 0 {
+		dst = append(dst, open...)
+		dst = appendLine(dst, s, open, reapply)
+
+		return append(dst, close...)
+	}
+
+	for len(s) > 0 {
+		line, newLine := s, ""
+		if index := strings.IndexByte(s, '\n'); index >= 0 {
+			line, newLine, s = s[:index], "\n", s[index+1:]
+		} else {
+			s = ""
+		}
+
+		if strings.HasSuffix(line, "\r") {
+			line, newLine = line[:len(line)-1], "\r"+newLine
+		}
+
+		if len(line) > 0 || mode == FillLines {
+			dst = append(dst, open...)
+			dst = appendLine(dst, line, open, reapply)
+			if mode == FillLines {
+				dst = append(dst, eraseLineSequence...)
+			}
+			dst = append(dst, close...)
+		}
+
+		dst = append(dst, newLine...)
+	}
+
+	return dst
+}
+
+func appendLine(dst []byte, line string, open []byte, reapply bool) []byte {
+	if reapply {
+		return appendReapplied(dst, line, open)
+	}
+
+	return append(dst, line...)
+}
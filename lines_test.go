@@ -0,0 +1,82 @@
+package colorize
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestLineModes(t *testing.T) {
+	t.Parallel()
+
+	style := Style{
+		Background: RGB(0, 0, 255),
+	}
+	testCases := []struct {
+		id       string
+		input    string
+		mode     LineMode
+		expected string
+	}{
+		{
+			id:       "Should span the style over the lines.",
+			input:    "first\nsecond\n",
+			mode:     SpanLines,
+			expected: "\x1b[48;2;0;0;255mfirst\nsecond\n\x1b[0m",
+		},
+		{
+			id:       "Should reset the style before each new line.",
+			input:    "first\nsecond\n",
+			mode:     ResetLines,
+			expected: "\x1b[48;2;0;0;255mfirst\x1b[0m\n\x1b[48;2;0;0;255msecond\x1b[0m\n",
+		},
+		{
+			id:       "Should skip the empty lines, and keep the carriage returns.",
+			input:    "first\r\n\r\nsecond",
+			mode:     ResetLines,
+			expected: "\x1b[48;2;0;0;255mfirst\x1b[0m\r\n\r\n\x1b[48;2;0;0;255msecond\x1b[0m",
+		},
+		{
+			id:       "Should fill the background till the end of each line.",
+			input:    "first\n\nsecond",
+			mode:     FillLines,
+			expected: "\x1b[48;2;0;0;255mfirst\x1b[K\x1b[0m\n\x1b[48;2;0;0;255m\x1b[K\x1b[0m\n\x1b[48;2;0;0;255msecond\x1b[K\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			colorized := NewColorable(output).EnableColor().SetLineMode(testCase.mode)
+
+			assert.Equal(t, testCase.mode, colorized.LineMode())
+			assert.Equal(
+				t,
+				fmt.Sprintf("%q", testCase.expected),
+				fmt.Sprintf("%q", colorized.Sprint(style, testCase.input)),
+				"colorize.Sprint()",
+			)
+
+			colorized.Fprint(output, style, testCase.input)
+			assert.Equal(t, fmt.Sprintf("%q", testCase.expected), fmt.Sprintf("%q", output.String()), "colorize.Fprint()")
+
+			output.Reset()
+			colorized.Set(style)
+			colorized.WriteString(testCase.input)
+			assert.Equal(t, fmt.Sprintf("%q", testCase.expected), fmt.Sprintf("%q", output.String()), "colorize.Write()")
+		})
+	}
+}
+
+func TestLineModeWithDisabledColor(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(os.Stdout).DisableColor().SetLineMode(FillLines)
+	style := Style{
+		Link: Link{URL: "https://example.com"},
+	}
+
+	assert.Equal(t, "first\nsecond (https://example.com)", colorized.Sprint(style, "first\nsecond"))
+}
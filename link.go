@@ -0,0 +1,60 @@
+package colorize
+
+type (
+	// Link target of an OSC 8 hyperlink, e.g. Link{URL: "https://example.com"}.
+	Link struct {
+		URL string
+		// ID optionally groups the separated parts of the same link,
+		// e.g. when the text is wrapped over multiple lines.
+		ID string
+	}
+)
+
+const (
+	// hyperlinkPrefix starts the OSC 8 sequences, e.g. \x1b]8;id=1;https://example.com\x1b\\
+	hyperlinkPrefix = "\x1b]8;"
+	// hyperlinkTerminator of the OSC sequences (ST).
+	hyperlinkTerminator = "\x1b\\"
+	// hyperlinkClose ends the hyperlink, as an OSC 8 with empty parameters and URL.
+	hyperlinkClose = hyperlinkPrefix + ";" + hyperlinkTerminator
+)
+
+// IsZero returns true if the link has no target.
+func (l Link) IsZero() bool {
+	return l.URL == ""
+}
+
+// appendOpen appends the OSC 8 sequence that starts the hyperlink.
+func (l Link) appendOpen(dst []byte) []byte {
+	dst = append(dst, hyperlinkPrefix...)
+	if l.ID != "" {
+		dst = append(dst, "id="...)
+		dst = appendOSCParameter(dst, l.ID, true)
+	}
+	dst = append(dst, ';')
+	dst = appendOSCParameter(dst, l.URL, false)
+
+	return append(dst, hyperlinkTerminator...)
+}
+
+// appendFallback appends the link target for terminals without hyperlinks, as in " (url)".
+func (l Link) appendFallback(dst []byte) []byte {
+	dst = append(dst, " ("...)
+	dst = appendOSCParameter(dst, l.URL, false)
+
+	return append(dst, ')')
+}
+
+// appendOSCParameter appends the value, dropping the control characters that
+// would terminate the sequence early, and the ":" and ";" separators of the parameters.
+func appendOSCParameter(dst []byte, value string, isParameter bool) []byte {
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+		if char < 0x20 || char == 0x7f || (isParameter && (char == ':' || char == ';')) {
+			continue
+		}
+		dst = append(dst, char)
+	}
+
+	return dst
+}
@@ -0,0 +1,93 @@
+package colorize
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestHyperlink(t *testing.T) {
+	t.Parallel()
+
+	style := Style{
+		Foreground: RGB(0, 0, 255),
+	}
+	testCases := []struct {
+		id        string
+		colorable *Colorable
+		url       string
+		expected  string
+	}{
+		{
+			id:        "Should output an OSC 8 hyperlink around the styled text.",
+			colorable: NewColorable(os.Stdout).EnableColor(),
+			url:       "https://example.com/PR-1",
+			expected:  "\x1b]8;;https://example.com/PR-1\x1b\\\x1b[38;2;0;0;255mPR-1\x1b[0m\x1b]8;;\x1b\\",
+		},
+		{
+			id:        "Should drop the control characters of the url.",
+			colorable: NewColorable(os.Stdout).EnableColor(),
+			url:       "https://example.com/\x1b\\PR-1\a",
+			expected:  "\x1b]8;;https://example.com/\\PR-1\x1b\\\x1b[38;2;0;0;255mPR-1\x1b[0m\x1b]8;;\x1b\\",
+		},
+		{
+			id:        "Should fall back to text and url without hyperlinks support.",
+			colorable: NewColorable(os.Stdout).EnableColor().SetProfile(ExtendedProfile.Without(Hyperlinks)),
+			url:       "https://example.com/PR-1",
+			expected:  "\x1b[38;2;0;0;255mPR-1\x1b[0m (https://example.com/PR-1)",
+		},
+		{
+			id:        "Should fall back to text and url when the color is disabled.",
+			colorable: NewColorable(os.Stdout).DisableColor(),
+			url:       "https://example.com/PR-1",
+			expected:  "PR-1 (https://example.com/PR-1)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(
+				t,
+				fmt.Sprintf("%q", testCase.expected),
+				fmt.Sprintf("%q", testCase.colorable.Hyperlink(testCase.url, "PR-1", style)),
+			)
+		})
+	}
+}
+
+func TestLinkCompile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id            string
+		input         Style
+		expectedOpen  string
+		expectedClose string
+	}{
+		{
+			id: "Should include the link id parameter.",
+			input: Style{
+				Font: Fonts(Underline),
+				Link: Link{URL: "https://example.com", ID: "ticket;1"},
+			},
+			expectedOpen:  "\x1b]8;id=ticket1;https://example.com\x1b\\\x1b[4m",
+			expectedClose: "\x1b[0m\x1b]8;;\x1b\\",
+		},
+		{
+			id:            "Should not include a hyperlink without url.",
+			input:         Style{Font: Fonts(Underline)},
+			expectedOpen:  "\x1b[4m",
+			expectedClose: "\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			compiled := testCase.input.Compile()
+
+			assert.Equal(t, testCase.expectedOpen, compiled.Open())
+			assert.Equal(t, testCase.expectedClose, compiled.Close())
+		})
+	}
+}
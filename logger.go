@@ -0,0 +1,176 @@
+package colorize
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type (
+	// LogStyles of the log line parts.
+	LogStyles struct {
+		Prefix    Style
+		Timestamp Style
+		Source    Style
+		Message   Style
+		// Level tokens found at the message start, as in "ERROR:" or "[warn]".
+		DebugLevel Style
+		InfoLevel  Style
+		WarnLevel  Style
+		ErrorLevel Style
+	}
+
+	// LogWriter colors the lines written by a log.Logger, splitting them by the logger prefix and flags.
+	LogWriter struct {
+		mux       sync.Mutex // protects the writes
+		colorable *Colorable
+		logger    *log.Logger
+		output    io.Writer
+		plain     bool
+		styles    LogStyles
+	}
+)
+
+// DefaultLogStyles of the LogWriter.
+var DefaultLogStyles = LogStyles{
+	Prefix:     Style{Font: Fonts(Bold)},
+	Timestamp:  Style{Font: Fonts(Faint)},
+	Source:     Style{Font: Fonts(Faint, Underline)},
+	DebugLevel: Style{Foreground: RGB(128, 128, 128), Font: Fonts(Bold)},
+	InfoLevel:  Style{Foreground: RGB(0, 255, 0), Font: Fonts(Bold)},
+	WarnLevel:  Style{Foreground: RGB(255, 255, 0), Font: Fonts(Bold)},
+	ErrorLevel: Style{Foreground: RGB(255, 0, 0), Font: Fonts(Bold)},
+}
+
+// logMsgPrefix is the log.Lmsgprefix flag, moving the prefix before the message, defined by Go 1.14 onwards.
+const logMsgPrefix = 1 << 6
+
+var (
+	// logSource of the Lshortfile and Llongfile flags, as in "main.go:12: ".
+	logSource = regexp.MustCompile(`^.*?:\d+: `)
+	// logLevel at the message start, as in "ERROR: ", "[warn] " or "INFO ".
+	logLevel = regexp.MustCompile(`(?i)^(\[(debug|info|warn|warning|error|err|fatal|panic)\]|(debug|info|warn|warning|error|err|fatal|panic)\b:?)`)
+)
+
+// NewLogger returns a log.Logger writing colored lines into the Colorable output.
+// e.g.: logger := NewLogger(NewColorable(os.Stderr), "api ", log.LstdFlags|log.Lshortfile)
+func NewLogger(c *Colorable, prefix string, flags int) *log.Logger {
+	logger := log.New(ioutil.Discard, prefix, flags)
+	logger.SetOutput(NewLogWriter(c, logger))
+
+	return logger
+}
+
+// NewLogWriter returns a writer coloring the lines of the logger, following its prefix and flags.
+// The lines are not colored if the Colorable output is not a terminal, unless the color is enabled explicitly.
+// e.g.: log.SetOutput(NewLogWriter(NewColorable(os.Stderr), log.Default()))
+func NewLogWriter(c *Colorable, logger *log.Logger) *LogWriter {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return &LogWriter{
+		colorable: c,
+		logger:    logger,
+		output:    c.output,
+		plain:     c.isColorActive == nil && !isTerminal(c.output),
+		styles:    DefaultLogStyles,
+	}
+}
+
+// SetStyles sets the styles of the log line parts.
+func (lw *LogWriter) SetStyles(styles LogStyles) *LogWriter {
+	lw.mux.Lock()
+	defer lw.mux.Unlock()
+
+	lw.styles = styles
+
+	return lw
+}
+
+// Write writes the log line with its parts styled, and returns len(p) on success.
+func (lw *LogWriter) Write(p []byte) (n int, err error) {
+	lw.mux.Lock()
+	defer lw.mux.Unlock()
+
+	if _, err = lw.output.Write(lw.appendLine(make([]byte, 0, len(p)+4*maxSequenceLength), string(p))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// appendLine splits the line as written by log.Logger: prefix, date, time, source, message prefix and message.
+func (lw *LogWriter) appendLine(dst []byte, line string) []byte {
+	prefix, flags := lw.logger.Prefix(), lw.logger.Flags()
+
+	if flags&logMsgPrefix == 0 && prefix != "" && strings.HasPrefix(line, prefix) {
+		dst = lw.appendStyled(dst, lw.styles.Prefix, prefix)
+		line = line[len(prefix):]
+	}
+
+	timestampLength := 0
+	if flags&log.Ldate != 0 {
+		timestampLength += len("2009/01/23 ")
+	}
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		timestampLength += len("01:23:23 ")
+		if flags&log.Lmicroseconds != 0 {
+			timestampLength += len(".123123")
+		}
+	}
+	if timestampLength > 0 && timestampLength <= len(line) {
+		dst = lw.appendStyled(dst, lw.styles.Timestamp, line[:timestampLength-1])
+		dst = append(dst, ' ')
+		line = line[timestampLength:]
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if source := logSource.FindString(line); source != "" {
+			dst = lw.appendStyled(dst, lw.styles.Source, source[:len(source)-len(": ")])
+			dst = append(dst, ": "...)
+			line = line[len(source):]
+		}
+	}
+
+	if flags&logMsgPrefix != 0 && prefix != "" && strings.HasPrefix(line, prefix) {
+		dst = lw.appendStyled(dst, lw.styles.Prefix, prefix)
+		line = line[len(prefix):]
+	}
+
+	message, hasNewLine := strings.TrimSuffix(line, "\n"), strings.HasSuffix(line, "\n")
+	if level := logLevel.FindString(message); level != "" {
+		dst = lw.appendStyled(dst, lw.levelStyle(strings.Trim(level, "[]:")), level)
+		message = message[len(level):]
+	}
+	dst = lw.appendStyled(dst, lw.styles.Message, message)
+
+	if hasNewLine {
+		dst = append(dst, '\n')
+	}
+
+	return dst
+}
+
+func (lw *LogWriter) levelStyle(level string) Style {
+	switch strings.ToLower(level) {
+	case "debug":
+		return lw.styles.DebugLevel
+	case "info":
+		return lw.styles.InfoLevel
+	case "warn", "warning":
+		return lw.styles.WarnLevel
+	}
+
+	return lw.styles.ErrorLevel
+}
+
+func (lw *LogWriter) appendStyled(dst []byte, style Style, s string) []byte {
+	if lw.plain || style.IsZero() || s == "" {
+		return append(dst, s...)
+	}
+
+	return lw.colorable.Append(dst, style, s)
+}
@@ -0,0 +1,108 @@
+package colorize
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		prefix   string
+		flags    int
+		message  string
+		expected string
+	}{
+		{
+			id:       "Should color the prefix and the level.",
+			prefix:   "api ",
+			message:  "ERROR: failed",
+			expected: "\x1b[1mapi \x1b[0m\x1b[38;2;255;0;0;1mERROR:\x1b[0m failed\n",
+		},
+		{
+			id:       "Should color the bracketed levels regardless of their case.",
+			message:  "[warn] slow",
+			expected: "\x1b[38;2;255;255;0;1m[warn]\x1b[0m slow\n",
+		},
+		{
+			id:       "Should not color the words starting by a level.",
+			message:  "information",
+			expected: "information\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			logger := NewLogger(NewColorable(output).EnableColor(), testCase.prefix, testCase.flags)
+
+			logger.Print(testCase.message)
+
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestLoggerTimestamp(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	logger := NewLogger(NewColorable(output).EnableColor(), "", log.LstdFlags|log.Lmicroseconds)
+
+	logger.Print("DEBUG started")
+
+	assert.Regexp(
+		t,
+		`^\x1b\[2m\d{4}/\d\d/\d\d \d\d:\d\d:\d\d\.\d{6}\x1b\[0m \x1b\[38;2;128;128;128;1mDEBUG\x1b\[0m started\n$`,
+		output.String(),
+	)
+}
+
+func TestLoggerSource(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	logger := NewLogger(NewColorable(output).EnableColor(), "db: ", log.Lshortfile|logMsgPrefix)
+
+	logger.Print("INFO ready")
+
+	assert.Regexp(
+		t,
+		`^\x1b\[2;4mlogger_test\.go:\d+\x1b\[0m: \x1b\[1mdb: \x1b\[0m\x1b\[38;2;0;255;0;1mINFO\x1b\[0m ready\n$`,
+		output.String(),
+	)
+}
+
+func TestLogWriter(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	logger := log.New(nil, "", 0)
+	logger.SetOutput(NewLogWriter(NewColorable(output), logger))
+
+	logger.Println("ERROR failed")
+
+	assert.Equal(t, "ERROR failed\n", output.String(), "Should not color the output, not being a terminal.")
+
+	output.Reset()
+	logger.SetOutput(NewLogWriter(NewColorable(output).EnableColor(), logger).SetStyles(LogStyles{}))
+	logger.Println("ERROR failed")
+
+	assert.Equal(t, "ERROR failed\n", output.String(), "Should not color the parts without styles.")
+}
+
+func TestLogWriterError(t *testing.T) {
+	t.Parallel()
+
+	logger := log.New(nil, "", 0)
+	writer := NewLogWriter(NewColorable(failingWriter{}), logger)
+
+	n, err := writer.Write([]byte("line\n"))
+
+	assert.Zero(t, n)
+	assert.EqualError(t, err, "write failed")
+}
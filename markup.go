@@ -0,0 +1,337 @@
+package colorize
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type (
+	// markupParser parses the markup tags into segments, see Colorable.ParseMarkup().
+	markupParser struct {
+		colorable *Colorable
+		// strict fails on the invalid tags, instead of keeping them as text.
+		strict   bool
+		styles   []Style
+		tags     []string
+		segments []Segment
+	}
+
+	// namedColor of the markup tags and the direct color helpers.
+	namedColor struct {
+		// rgb of the color, unless a palette is set.
+		rgb [3]byte
+		// indexes of the palette colors, blended for the colors missing from the ANSI ones.
+		indexes []byte
+		// muted colors follow Palette.MutedColor(), so they stay visible on the palette background.
+		muted bool
+	}
+)
+
+const (
+	markupOpen   = '['
+	markupClose  = ']'
+	markupEscape = '\\'
+	markupEnd    = '/'
+)
+
+// markupEffects maps the effect names of the markup tags to the font effects.
+var markupEffects = map[string]FontEffect{
+	"bold":             Bold,
+	"dim":              Faint,
+	"faint":            Faint,
+	"italic":           Italic,
+	"underline":        Underline,
+	"blink":            BlinkSlow,
+	"rapid-blink":      BlinkRapid,
+	"reverse":          ReverseVideo,
+	"conceal":          Concealed,
+	"hidden":           Concealed,
+	"strike":           CrossedOut,
+	"double-underline": DoublyUnderlined,
+	"framed":           Framed,
+	"encircled":        Encircled,
+	"overline":         Overlined,
+	"superscript":      Superscript,
+	"subscript":        Subscript,
+}
+
+// markupUnderlineStyles maps the underline style names of the markup tags.
+var markupUnderlineStyles = map[string]UnderlineStyle{
+	"curly-underline":  CurlyUnderline,
+	"dotted-underline": DottedUnderline,
+	"dashed-underline": DashedUnderline,
+}
+
+// namedColors of the markup tags, matching the direct color helpers, e.g. Colorable.Red().
+var namedColors = map[string]namedColor{
+	"black":   {rgb: [3]byte{0, 0, 0}, indexes: []byte{ANSIBlack}},
+	"blue":    {rgb: [3]byte{0, 0, 255}, indexes: []byte{ANSIBlue}},
+	"cyan":    {rgb: [3]byte{0, 255, 255}, indexes: []byte{ANSICyan}},
+	"gray":    {rgb: [3]byte{128, 128, 128}, muted: true},
+	"green":   {rgb: [3]byte{0, 255, 0}, indexes: []byte{ANSIGreen}},
+	"magenta": {rgb: [3]byte{255, 0, 255}, indexes: []byte{ANSIMagenta}},
+	"orange":  {rgb: [3]byte{255, 165, 0}, indexes: []byte{ANSIRed, ANSIYellow}},
+	"purple":  {rgb: [3]byte{128, 0, 128}, indexes: []byte{ANSIMagenta, ANSIBlue}},
+	"red":     {rgb: [3]byte{255, 0, 0}, indexes: []byte{ANSIRed}},
+	"white":   {rgb: [3]byte{255, 255, 255}, indexes: []byte{ANSIWhite}},
+	"yellow":  {rgb: [3]byte{255, 255, 0}, indexes: []byte{ANSIYellow}},
+}
+
+// Markup renders the markup into styled text for the standard output, see Colorable.Markup().
+// e.g.: Markup("[bold red]Error:[/] file [underline #88c0d0]main.go[/] not found")
+func Markup(s string) string {
+	return NewColorable(os.Stdout).Markup(s)
+}
+
+// EscapeMarkup escapes the brackets of s, so it is kept as is inside a markup.
+// e.g.: Markup("[bold]" + EscapeMarkup(path) + "[/]")
+func EscapeMarkup(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`).Replace(s)
+}
+
+// ParseMarkup parses the markup into segments, failing on the invalid or unbalanced tags.
+// A tag lists the space separated styles applied till its closing [/] tag, and inherits the enclosing tags:
+//   - effects: bold, dim, italic, underline, strike, reverse...
+//   - colors: names as red, following SetPalette(), hex as #88c0d0 or #abc, and CSS as rgb(136, 192, 208), prefixed by "on" for the background.
+//   - links: link=https://example.com
+//   - roles of the theme, as error or heading, see SetTheme() and DefineStyle().
+//
+// The brackets are escaped by a backslash, as in \[literal].
+func (c *Colorable) ParseMarkup(s string) ([]Segment, error) {
+	return c.newMarkupParser(true).parse(s)
+}
+
+// Markup renders the markup into styled text, keeping the invalid tags as text, see ParseMarkup().
+func (c *Colorable) Markup(s string) string {
+	segments, _ := c.newMarkupParser(false).parse(s)
+
+	styled := make([]byte, 0, len(s)+len(segments)*2*maxSequenceLength)
+	for _, segment := range segments {
+		if segment.Style.IsZero() {
+			styled = append(styled, segment.Text...)
+
+			continue
+		}
+		styled = c.Append(styled, segment.Style, segment.Text)
+	}
+
+	return string(styled)
+}
+
+func (c *Colorable) newMarkupParser(strict bool) *markupParser {
+	return &markupParser{
+		colorable: c,
+		strict:    strict,
+		styles:    []Style{{}},
+	}
+}
+
+func (mp *markupParser) parse(s string) ([]Segment, error) {
+	text := make([]byte, 0, len(s))
+
+	for index := 0; index < len(s); index++ {
+		char := s[index]
+
+		switch {
+		case char == markupEscape && index+1 < len(s) && (s[index+1] == markupOpen || s[index+1] == markupEscape):
+			index++
+			text = append(text, s[index])
+		case char == markupOpen:
+			end := strings.IndexByte(s[index:], markupClose)
+			if end < 0 {
+				if mp.strict {
+					return nil, fmt.Errorf("markup: unterminated tag at %d", index)
+				}
+				text = append(text, char)
+
+				continue
+			}
+
+			tag := s[index+1 : index+end]
+			mp.appendText(text)
+			text = text[:0]
+
+			if err := mp.applyTag(tag); err != nil {
+				if mp.strict {
+					return nil, fmt.Errorf("markup: %v at %d", err, index)
+				}
+				text = append(text, s[index:index+end+1]...)
+			}
+			index += end
+		default:
+			text = append(text, char)
+		}
+	}
+	mp.appendText(text)
+
+	if mp.strict && len(mp.tags) > 0 {
+		return nil, fmt.Errorf("markup: unclosed tag [%s]", mp.tags[len(mp.tags)-1])
+	}
+
+	return mp.segments, nil
+}
+
+// applyTag opens or closes a tag, a closing tag might repeat the opening one, as in [bold]text[/bold].
+func (mp *markupParser) applyTag(tag string) error {
+	if strings.HasPrefix(tag, string(markupEnd)) {
+		name := strings.TrimSpace(tag[1:])
+		last := len(mp.tags) - 1
+		if last < 0 {
+			return fmt.Errorf("closing tag [%s] without an opening one", tag)
+		}
+
+		if name != "" && name != mp.tags[last] {
+			return fmt.Errorf("closing tag [%s] of the opening [%s]", tag, mp.tags[last])
+		}
+
+		mp.tags = mp.tags[:last]
+		mp.styles = mp.styles[:len(mp.styles)-1]
+
+		return nil
+	}
+
+	style, err := mp.parseStyle(tag)
+	if err != nil {
+		return err
+	}
+
+	mp.tags = append(mp.tags, strings.TrimSpace(tag))
+	mp.styles = append(mp.styles, style.Inherit(mp.styles[len(mp.styles)-1]))
+
+	return nil
+}
+
+// parseStyle parses the styles of a tag, the later ones override the earlier ones.
+func (mp *markupParser) parseStyle(tag string) (Style, error) {
+	style := Style{}
+	words := strings.Fields(normalizeFunctions(tag))
+	if len(words) == 0 {
+		return style, fmt.Errorf("empty tag")
+	}
+
+	for index := 0; index < len(words); index++ {
+		word := words[index]
+
+		if effect, ok := markupEffects[word]; ok {
+			style.Font = style.Font.Set(effect)
+
+			continue
+		}
+
+		if underlineStyle, ok := markupUnderlineStyles[word]; ok {
+			style.UnderlineStyle = underlineStyle
+
+			continue
+		}
+
+		if strings.HasPrefix(word, "link=") {
+			style.Link = Link{URL: word[len("link="):]}
+
+			continue
+		}
+
+		if namedStyle, ok := mp.colorable.NamedStyle(word); ok {
+			style = namedStyle.Inherit(style)
+
+			continue
+		}
+
+		if word == "on" && index+1 < len(words) {
+			index++
+			background, err := mp.colorable.parseMarkupColor(words[index])
+			if err != nil {
+				return style, err
+			}
+			style.Background = background
+
+			continue
+		}
+
+		foreground, err := mp.colorable.parseMarkupColor(word)
+		if err != nil {
+			return style, err
+		}
+		style.Foreground = foreground
+	}
+
+	return style, nil
+}
+
+func (mp *markupParser) appendText(text []byte) {
+	if len(text) == 0 {
+		return
+	}
+
+	style := mp.styles[len(mp.styles)-1]
+	if last := len(mp.segments) - 1; last >= 0 && mp.segments[last].Style.Equals(style) {
+		mp.segments[last].Text += string(text)
+
+		return
+	}
+
+	mp.segments = append(mp.segments, Segment{Text: string(text), Style: style})
+}
+
+// parseMarkupColor parses a named, a hex or a CSS rgb() color, the named ones follow the Colorable palette.
+func (c *Colorable) parseMarkupColor(word string) (Color, error) {
+	if color, ok := c.NamedColor(word); ok {
+		return color, nil
+	}
+
+	if strings.HasPrefix(word, "#") && (len(word) == 4 || len(word) == 7) && isHexadecimal(word[1:]) {
+		return Hex(word)
+	}
+
+	if word = normalizeFunctions(word); strings.HasPrefix(word, "rgb(") && strings.HasSuffix(word, ")") {
+		components := strings.Split(word[len("rgb("):len(word)-1], ",")
+		if len(components) == 3 {
+			var rgb [3]byte
+			for index, component := range components {
+				value, err := strconv.ParseUint(component, 10, 8)
+				if err != nil {
+					return nil, fmt.Errorf("invalid color %q", word)
+				}
+				rgb[index] = byte(value)
+			}
+
+			return RGB(rgb[0], rgb[1], rgb[2]), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown style %q", word)
+}
+
+// normalizeFunctions removes the spaces inside the parentheses, as in rgb(1, 2, 3).
+func normalizeFunctions(tag string) string {
+	if !strings.ContainsRune(tag, '(') {
+		return tag
+	}
+
+	normalized := make([]byte, 0, len(tag))
+	depth := 0
+	for index := 0; index < len(tag); index++ {
+		switch char := tag[index]; {
+		case char == '(':
+			depth++
+		case char == ')' && depth > 0:
+			depth--
+		case char == ' ' && depth > 0:
+			continue
+		}
+		normalized = append(normalized, tag[index])
+	}
+
+	return string(normalized)
+}
+
+func isHexadecimal(s string) bool {
+	for index := 0; index < len(s); index++ {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", rune(s[index])) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,156 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(nil).DefineStyle("path", Style{Foreground: RGB(136, 192, 208), Font: Fonts(Underline)})
+	red := RGB(255, 0, 0)
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected []Segment
+	}{
+		{
+			id:       "Should return the plain text as is.",
+			input:    "plain text",
+			expected: []Segment{{Text: "plain text"}},
+		},
+		{
+			id:    "Should parse the effects and the named colors.",
+			input: "[bold red]Error:[/] not found",
+			expected: []Segment{
+				{Text: "Error:", Style: Style{Foreground: red, Font: Fonts(Bold)}},
+				{Text: " not found"},
+			},
+		},
+		{
+			id:    "Should inherit the enclosing tags.",
+			input: "[italic on #abc]outer [rgb(1, 2, 3) strike]inner[/] outer[/italic on #abc]",
+			expected: []Segment{
+				{Text: "outer ", Style: Style{Background: RGB(0xaa, 0xbb, 0xcc), Font: Fonts(Italic)}},
+				{Text: "inner", Style: Style{Foreground: RGB(1, 2, 3), Background: RGB(0xaa, 0xbb, 0xcc), Font: Fonts(Italic, CrossedOut)}},
+				{Text: " outer", Style: Style{Background: RGB(0xaa, 0xbb, 0xcc), Font: Fonts(Italic)}},
+			},
+		},
+		{
+			id:    "Should parse the named styles, the underline styles and the links.",
+			input: "file [path bold]main.go[/] [curly-underline link=https://example.com]link[/]",
+			expected: []Segment{
+				{Text: "file "},
+				{Text: "main.go", Style: Style{Foreground: RGB(136, 192, 208), Font: Fonts(Bold, Underline)}},
+				{Text: " "},
+				{Text: "link", Style: Style{UnderlineStyle: CurlyUnderline, Link: Link{URL: "https://example.com"}}},
+			},
+		},
+		{
+			id:    "Should keep the escaped brackets.",
+			input: `\[bold] [red]a\\b[/]`,
+			expected: []Segment{
+				{Text: "[bold] "},
+				{Text: `a\b`, Style: Style{Foreground: red}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			segments, err := colorized.ParseMarkup(testCase.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, segments)
+		})
+	}
+}
+
+func TestParseMarkupError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected string
+	}{
+		{
+			id:       "Should fail on an unknown style.",
+			input:    "items[0]",
+			expected: `markup: unknown style "0" at 5`,
+		},
+		{
+			id:       "Should fail on an invalid color.",
+			input:    "[rgb(1,2,300)]text",
+			expected: `markup: invalid color "rgb(1,2,300)" at 0`,
+		},
+		{
+			id:       "Should fail on an unterminated tag.",
+			input:    "[bold",
+			expected: "markup: unterminated tag at 0",
+		},
+		{
+			id:       "Should fail on a closing tag without an opening one.",
+			input:    "text[/]",
+			expected: "markup: closing tag [/] without an opening one at 4",
+		},
+		{
+			id:       "Should fail on a mismatched closing tag.",
+			input:    "[bold]text[/italic]",
+			expected: "markup: closing tag [/italic] of the opening [bold] at 10",
+		},
+		{
+			id:       "Should fail on an unclosed tag.",
+			input:    "[bold]text",
+			expected: "markup: unclosed tag [bold]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			_, err := NewColorable(nil).ParseMarkup(testCase.input)
+
+			assert.EqualError(t, err, testCase.expected)
+		})
+	}
+}
+
+func TestMarkup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected string
+	}{
+		{
+			id:       "Should render the styled segments.",
+			input:    "[bold red]Error:[/] not found",
+			expected: "\x1b[38;2;255;0;0;1mError:\x1b[0m not found",
+		},
+		{
+			id:       "Should keep the invalid tags as text.",
+			input:    "items[0] [/] [bold]text",
+			expected: "items[0] [/] \x1b[1mtext\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, NewColorable(nil).EnableColor().Markup(testCase.input))
+		})
+	}
+
+	assert.Equal(t, "Error: not found", NewColorable(nil).DisableColor().Markup("[bold red]Error:[/] not found"))
+}
+
+func TestEscapeMarkup(t *testing.T) {
+	t.Parallel()
+
+	escaped := EscapeMarkup(`[bold] a\b`)
+
+	assert.Equal(t, `\[bold] a\\b`, escaped)
+	assert.Equal(t, `[bold] a\b`, NewColorable(nil).Markup(escaped))
+}
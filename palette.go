@@ -0,0 +1,167 @@
+package colorize
+
+import "math"
+
+type (
+	// Palette maps the indexed colors of the 16/256 colors sequences to RGB values.
+	Palette struct {
+		// ANSI colors, ordered as black, red, green, yellow, blue, magenta, cyan and white,
+		// followed by their bright variants.
+		ANSI [16]Color
+		// Foreground, Background, Cursor and Selection colors of the terminal, nil if they are not set.
+		Foreground Color
+		Background Color
+		Cursor     Color
+		Selection  Color
+		// Name of the color scheme, if any.
+		Name string
+	}
+)
+
+// Indexes of the ANSI colors.
+const (
+	ANSIBlack byte = iota
+	ANSIRed
+	ANSIGreen
+	ANSIYellow
+	ANSIBlue
+	ANSIMagenta
+	ANSICyan
+	ANSIWhite
+	ANSIBrightBlack
+	ANSIBrightRed
+	ANSIBrightGreen
+	ANSIBrightYellow
+	ANSIBrightBlue
+	ANSIBrightMagenta
+	ANSIBrightCyan
+	ANSIBrightWhite
+)
+
+var (
+	// XtermPalette of the xterm default colors.
+	XtermPalette = Palette{
+		Name: "xterm",
+		ANSI: [16]Color{
+			RGB(0x00, 0x00, 0x00),
+			RGB(0xcd, 0x00, 0x00),
+			RGB(0x00, 0xcd, 0x00),
+			RGB(0xcd, 0xcd, 0x00),
+			RGB(0x00, 0x00, 0xee),
+			RGB(0xcd, 0x00, 0xcd),
+			RGB(0x00, 0xcd, 0xcd),
+			RGB(0xe5, 0xe5, 0xe5),
+			RGB(0x7f, 0x7f, 0x7f),
+			RGB(0xff, 0x00, 0x00),
+			RGB(0x00, 0xff, 0x00),
+			RGB(0xff, 0xff, 0x00),
+			RGB(0x5c, 0x5c, 0xff),
+			RGB(0xff, 0x00, 0xff),
+			RGB(0x00, 0xff, 0xff),
+			RGB(0xff, 0xff, 0xff),
+		},
+	}
+
+	// DefaultPalette used to resolve the indexed colors, unless a palette is given.
+	DefaultPalette = XtermPalette
+
+	// cubeLevels of the 6x6x6 colors cube of the 256 colors palette.
+	cubeLevels = [6]byte{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+)
+
+// Color returns the color of the given index of the 256 colors palette,
+// the ANSI colors come from the palette, falling back to the xterm ones,
+// followed by the 6x6x6 colors cube and the grayscale ramp.
+func (p Palette) Color(index byte) Color {
+	switch {
+	case index < 16:
+		if p.ANSI[index] != nil {
+			return p.ANSI[index]
+		}
+
+		return XtermPalette.ANSI[index]
+	case index < 232:
+		index -= 16
+
+		return RGB(cubeLevels[index/36], cubeLevels[index/6%6], cubeLevels[index%6])
+	default:
+		level := 8 + 10*(index-232)
+
+		return RGB(level, level, level)
+	}
+}
+
+// DefaultForeground returns the foreground color, falling back to the ANSI white.
+func (p Palette) DefaultForeground() Color {
+	if p.Foreground != nil {
+		return p.Foreground
+	}
+
+	return p.Color(ANSIWhite)
+}
+
+// DefaultBackground returns the background color, falling back to the ANSI black.
+func (p Palette) DefaultBackground() Color {
+	if p.Background != nil {
+		return p.Background
+	}
+
+	return p.Color(ANSIBlack)
+}
+
+// minimumMutedContrast of the muted color against the background, below which the text is barely visible.
+const minimumMutedContrast = 1.5
+
+// MutedColor returns the ANSI bright black, used for the muted text, unless it has too little contrast with the background,
+// as in the Solarized dark scheme, where the foreground and the background colors are blended instead.
+func (p Palette) MutedColor() Color {
+	muted, foreground, background := p.Color(ANSIBrightBlack), p.DefaultForeground(), p.DefaultBackground()
+	if contrastRatio(muted, background) >= minimumMutedContrast {
+		return muted
+	}
+
+	return RGB(
+		byte((int(foreground.Red())+int(background.Red()))/2),
+		byte((int(foreground.Green())+int(background.Green()))/2),
+		byte((int(foreground.Blue())+int(background.Blue()))/2),
+	)
+}
+
+// Theme returns the semantic roles styled by the palette colors, e.g. colorized.SetTheme(DraculaPalette.Theme()).
+func (p Palette) Theme() Theme {
+	return Theme{
+		ErrorRole:   {Foreground: p.Color(ANSIRed), Font: Fonts(Bold)},
+		WarningRole: {Foreground: p.Color(ANSIYellow)},
+		SuccessRole: {Foreground: p.Color(ANSIGreen)},
+		InfoRole:    {Foreground: p.Color(ANSIBlue)},
+		MutedRole:   {Foreground: p.MutedColor()},
+		AccentRole:  {Foreground: p.Color(ANSIMagenta)},
+		HeadingRole: {Foreground: p.DefaultForeground(), Font: Fonts(Bold, Underline)},
+		CodeRole:    {Foreground: p.Color(ANSICyan)},
+		LinkRole:    {Foreground: p.Color(ANSIBlue), Font: Fonts(Underline)},
+	}
+}
+
+// contrastRatio of the two colors, from 1 for the same luminance to 21 for black and white, as defined by WCAG.
+func contrastRatio(first, second Color) float64 {
+	lighter, darker := relativeLuminance(first), relativeLuminance(second)
+	if lighter < darker {
+		lighter, darker = darker, lighter
+	}
+
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+// relativeLuminance of the color, from 0 for black to 1 for white.
+func relativeLuminance(color Color) float64 {
+	linear := func(component byte) float64 {
+		value := float64(component) / 255
+		if value <= 0.03928 {
+			return value / 12.92
+		}
+
+		return math.Pow((value+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*linear(color.Red()) + 0.7152*linear(color.Green()) + 0.0722*linear(color.Blue())
+}
@@ -0,0 +1,90 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPaletteColor(t *testing.T) {
+	t.Parallel()
+
+	custom := Palette{}
+	custom.ANSI[ANSIRed] = RGB(0xdc, 0x32, 0x2f)
+
+	testCases := []struct {
+		id       string
+		palette  Palette
+		index    byte
+		expected Color
+	}{
+		{
+			id:       "Should return the ANSI color of the palette.",
+			palette:  custom,
+			index:    ANSIRed,
+			expected: RGB(0xdc, 0x32, 0x2f),
+		},
+		{
+			id:       "Should fall back to the xterm ANSI colors.",
+			palette:  custom,
+			index:    ANSIBrightBlue,
+			expected: RGB(0x5c, 0x5c, 0xff),
+		},
+		{
+			id:       "Should return the first color of the cube.",
+			palette:  XtermPalette,
+			index:    16,
+			expected: RGB(0x00, 0x00, 0x00),
+		},
+		{
+			id:       "Should return a color of the cube.",
+			palette:  XtermPalette,
+			index:    173,
+			expected: RGB(0xd7, 0x87, 0x5f),
+		},
+		{
+			id:       "Should return the last color of the cube.",
+			palette:  XtermPalette,
+			index:    231,
+			expected: RGB(0xff, 0xff, 0xff),
+		},
+		{
+			id:       "Should return the grayscale ramp.",
+			palette:  XtermPalette,
+			index:    255,
+			expected: RGB(0xee, 0xee, 0xee),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.palette.Color(testCase.index))
+		})
+	}
+}
+
+func TestPaletteDefaultColors(t *testing.T) {
+	t.Parallel()
+
+	palette := Palette{Foreground: RGB(0x83, 0x94, 0x96)}
+
+	assert.Equal(t, RGB(0x83, 0x94, 0x96), palette.DefaultForeground())
+	assert.Equal(t, XtermPalette.ANSI[ANSIBlack], palette.DefaultBackground(), "Should fall back to the ANSI black.")
+}
+
+func TestPaletteTheme(t *testing.T) {
+	t.Parallel()
+
+	theme := NordPalette.Theme()
+
+	assert.Equal(t, Style{Foreground: RGB(0xbf, 0x61, 0x6a), Font: Fonts(Bold)}, theme[ErrorRole])
+	assert.Equal(t, Style{Foreground: RGB(0xd8, 0xde, 0xe9), Font: Fonts(Bold, Underline)}, theme[HeadingRole])
+	assert.Equal(t, Style{Foreground: RGB(0x4c, 0x56, 0x6a)}, theme[MutedRole])
+}
+
+func TestPaletteMutedColor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, NordPalette.ANSI[ANSIBrightBlack], NordPalette.MutedColor())
+	assert.Equal(t, RGB(0x41, 0x5f, 0x66), SolarizedDarkPalette.MutedColor(), "Should blend the foreground and the background.")
+	assert.Equal(t, RGB(0x41, 0x5f, 0x66), SolarizedDarkPalette.Theme()[MutedRole].Foreground)
+}
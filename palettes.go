@@ -0,0 +1,253 @@
+package colorize
+
+import "strings"
+
+// Built-in palettes of the well-known color schemes, ANSI colors ordered as in Palette.ANSI.
+var (
+	// SolarizedDarkPalette of the Solarized dark scheme.
+	SolarizedDarkPalette = Palette{
+		Name: "Solarized Dark",
+		ANSI: hexColors(
+			0x073642, 0xdc322f, 0x859900, 0xb58900, 0x268bd2, 0xd33682, 0x2aa198, 0xeee8d5,
+			0x002b36, 0xcb4b16, 0x586e75, 0x657b83, 0x839496, 0x6c71c4, 0x93a1a1, 0xfdf6e3,
+		),
+		Foreground: hexColor(0x839496),
+		Background: hexColor(0x002b36),
+		Cursor:     hexColor(0x93a1a1),
+		Selection:  hexColor(0x073642),
+	}
+
+	// SolarizedLightPalette of the Solarized light scheme.
+	SolarizedLightPalette = Palette{
+		Name:       "Solarized Light",
+		ANSI:       SolarizedDarkPalette.ANSI,
+		Foreground: hexColor(0x657b83),
+		Background: hexColor(0xfdf6e3),
+		Cursor:     hexColor(0x586e75),
+		Selection:  hexColor(0xeee8d5),
+	}
+
+	// DraculaPalette of the Dracula scheme.
+	DraculaPalette = Palette{
+		Name: "Dracula",
+		ANSI: hexColors(
+			0x21222c, 0xff5555, 0x50fa7b, 0xf1fa8c, 0xbd93f9, 0xff79c6, 0x8be9fd, 0xf8f8f2,
+			0x6272a4, 0xff6e6e, 0x69ff94, 0xffffa5, 0xd6acff, 0xff92df, 0xa4ffff, 0xffffff,
+		),
+		Foreground: hexColor(0xf8f8f2),
+		Background: hexColor(0x282a36),
+		Cursor:     hexColor(0xf8f8f2),
+		Selection:  hexColor(0x44475a),
+	}
+
+	// NordPalette of the Nord scheme.
+	NordPalette = Palette{
+		Name: "Nord",
+		ANSI: hexColors(
+			0x3b4252, 0xbf616a, 0xa3be8c, 0xebcb8b, 0x81a1c1, 0xb48ead, 0x88c0d0, 0xe5e9f0,
+			0x4c566a, 0xbf616a, 0xa3be8c, 0xebcb8b, 0x81a1c1, 0xb48ead, 0x8fbcbb, 0xeceff4,
+		),
+		Foreground: hexColor(0xd8dee9),
+		Background: hexColor(0x2e3440),
+		Cursor:     hexColor(0xd8dee9),
+		Selection:  hexColor(0x434c5e),
+	}
+
+	// GruvboxDarkPalette of the Gruvbox dark scheme.
+	GruvboxDarkPalette = Palette{
+		Name: "Gruvbox Dark",
+		ANSI: hexColors(
+			0x282828, 0xcc241d, 0x98971a, 0xd79921, 0x458588, 0xb16286, 0x689d6a, 0xa89984,
+			0x928374, 0xfb4934, 0xb8bb26, 0xfabd2f, 0x83a598, 0xd3869b, 0x8ec07c, 0xebdbb2,
+		),
+		Foreground: hexColor(0xebdbb2),
+		Background: hexColor(0x282828),
+		Cursor:     hexColor(0xebdbb2),
+		Selection:  hexColor(0x504945),
+	}
+
+	// GruvboxLightPalette of the Gruvbox light scheme.
+	GruvboxLightPalette = Palette{
+		Name: "Gruvbox Light",
+		ANSI: hexColors(
+			0xfbf1c7, 0xcc241d, 0x98971a, 0xd79921, 0x458588, 0xb16286, 0x689d6a, 0x7c6f64,
+			0x928374, 0x9d0006, 0x79740e, 0xb57614, 0x076678, 0x8f3f71, 0x427b58, 0x3c3836,
+		),
+		Foreground: hexColor(0x3c3836),
+		Background: hexColor(0xfbf1c7),
+		Cursor:     hexColor(0x3c3836),
+		Selection:  hexColor(0xd5c4a1),
+	}
+
+	// CatppuccinLattePalette of the Catppuccin Latte flavour.
+	CatppuccinLattePalette = Palette{
+		Name: "Catppuccin Latte",
+		ANSI: hexColors(
+			0x5c5f77, 0xd20f39, 0x40a02b, 0xdf8e1d, 0x1e66f5, 0xea76cb, 0x179299, 0xacb0be,
+			0x6c6f85, 0xd20f39, 0x40a02b, 0xdf8e1d, 0x1e66f5, 0xea76cb, 0x179299, 0xbcc0cc,
+		),
+		Foreground: hexColor(0x4c4f69),
+		Background: hexColor(0xeff1f5),
+		Cursor:     hexColor(0xdc8a78),
+		Selection:  hexColor(0xacb0be),
+	}
+
+	// CatppuccinFrappePalette of the Catppuccin Frappé flavour.
+	CatppuccinFrappePalette = Palette{
+		Name: "Catppuccin Frappé",
+		ANSI: hexColors(
+			0x51576d, 0xe78284, 0xa6d189, 0xe5c890, 0x8caaee, 0xf4b8e4, 0x81c8be, 0xb5bfe2,
+			0x626880, 0xe78284, 0xa6d189, 0xe5c890, 0x8caaee, 0xf4b8e4, 0x81c8be, 0xa5adce,
+		),
+		Foreground: hexColor(0xc6d0f5),
+		Background: hexColor(0x303446),
+		Cursor:     hexColor(0xf2d5cf),
+		Selection:  hexColor(0x626880),
+	}
+
+	// CatppuccinMacchiatoPalette of the Catppuccin Macchiato flavour.
+	CatppuccinMacchiatoPalette = Palette{
+		Name: "Catppuccin Macchiato",
+		ANSI: hexColors(
+			0x494d64, 0xed8796, 0xa6da95, 0xeed49f, 0x8aadf4, 0xf5bde6, 0x8bd5ca, 0xb8c0e0,
+			0x5b6078, 0xed8796, 0xa6da95, 0xeed49f, 0x8aadf4, 0xf5bde6, 0x8bd5ca, 0xa5adcb,
+		),
+		Foreground: hexColor(0xcad3f5),
+		Background: hexColor(0x24273a),
+		Cursor:     hexColor(0xf4dbd6),
+		Selection:  hexColor(0x5b6078),
+	}
+
+	// CatppuccinMochaPalette of the Catppuccin Mocha flavour.
+	CatppuccinMochaPalette = Palette{
+		Name: "Catppuccin Mocha",
+		ANSI: hexColors(
+			0x45475a, 0xf38ba8, 0xa6e3a1, 0xf9e2af, 0x89b4fa, 0xf5c2e7, 0x94e2d5, 0xbac2de,
+			0x585b70, 0xf38ba8, 0xa6e3a1, 0xf9e2af, 0x89b4fa, 0xf5c2e7, 0x94e2d5, 0xa6adc8,
+		),
+		Foreground: hexColor(0xcdd6f4),
+		Background: hexColor(0x1e1e2e),
+		Cursor:     hexColor(0xf5e0dc),
+		Selection:  hexColor(0x585b70),
+	}
+
+	// OneDarkPalette of the Atom One Dark scheme.
+	OneDarkPalette = Palette{
+		Name: "One Dark",
+		ANSI: hexColors(
+			0x282c34, 0xe06c75, 0x98c379, 0xe5c07b, 0x61afef, 0xc678dd, 0x56b6c2, 0xabb2bf,
+			0x5c6370, 0xe06c75, 0x98c379, 0xe5c07b, 0x61afef, 0xc678dd, 0x56b6c2, 0xffffff,
+		),
+		Foreground: hexColor(0xabb2bf),
+		Background: hexColor(0x282c34),
+		Cursor:     hexColor(0x528bff),
+		Selection:  hexColor(0x3e4451),
+	}
+
+	// TokyoNightPalette of the Tokyo Night scheme.
+	TokyoNightPalette = Palette{
+		Name: "Tokyo Night",
+		ANSI: hexColors(
+			0x15161e, 0xf7768e, 0x9ece6a, 0xe0af68, 0x7aa2f7, 0xbb9af7, 0x7dcfff, 0xa9b1d6,
+			0x414868, 0xf7768e, 0x9ece6a, 0xe0af68, 0x7aa2f7, 0xbb9af7, 0x7dcfff, 0xc0caf5,
+		),
+		Foreground: hexColor(0xc0caf5),
+		Background: hexColor(0x1a1b26),
+		Cursor:     hexColor(0xc0caf5),
+		Selection:  hexColor(0x33467c),
+	}
+
+	// MonokaiPalette of the Monokai scheme.
+	MonokaiPalette = Palette{
+		Name: "Monokai",
+		ANSI: hexColors(
+			0x272822, 0xf92672, 0xa6e22e, 0xf4bf75, 0x66d9ef, 0xae81ff, 0xa1efe4, 0xf8f8f2,
+			0x75715e, 0xf92672, 0xa6e22e, 0xf4bf75, 0x66d9ef, 0xae81ff, 0xa1efe4, 0xf9f8f5,
+		),
+		Foreground: hexColor(0xf8f8f2),
+		Background: hexColor(0x272822),
+		Cursor:     hexColor(0xf8f8f0),
+		Selection:  hexColor(0x49483e),
+	}
+
+	// VGAPalette of the VGA text mode colors.
+	VGAPalette = Palette{
+		Name: "VGA",
+		ANSI: hexColors(
+			0x000000, 0xaa0000, 0x00aa00, 0xaa5500, 0x0000aa, 0xaa00aa, 0x00aaaa, 0xaaaaaa,
+			0x555555, 0xff5555, 0x55ff55, 0xffff55, 0x5555ff, 0xff55ff, 0x55ffff, 0xffffff,
+		),
+	}
+
+	// WindowsConsolePalette of the Windows console default colors, known as Campbell.
+	WindowsConsolePalette = Palette{
+		Name: "Windows Console",
+		ANSI: hexColors(
+			0x0c0c0c, 0xc50f1f, 0x13a10e, 0xc19c00, 0x0037da, 0x881798, 0x3a96dd, 0xcccccc,
+			0x767676, 0xe74856, 0x16c60c, 0xf9f1a5, 0x3b78ff, 0xb4009e, 0x61d6d6, 0xf2f2f2,
+		),
+		Foreground: hexColor(0xcccccc),
+		Background: hexColor(0x0c0c0c),
+		Cursor:     hexColor(0xffffff),
+	}
+)
+
+// builtInPalettes returns the built-in palettes, read when called so the changes of their variables are seen.
+func builtInPalettes() []Palette {
+	return []Palette{
+		XtermPalette,
+		VGAPalette,
+		WindowsConsolePalette,
+		SolarizedDarkPalette,
+		SolarizedLightPalette,
+		DraculaPalette,
+		NordPalette,
+		GruvboxDarkPalette,
+		GruvboxLightPalette,
+		CatppuccinLattePalette,
+		CatppuccinFrappePalette,
+		CatppuccinMacchiatoPalette,
+		CatppuccinMochaPalette,
+		OneDarkPalette,
+		TokyoNightPalette,
+		MonokaiPalette,
+	}
+}
+
+// LookupPalette returns the built-in palette of the given name, ignoring the case, and false if there is none.
+// e.g.: palette, ok := LookupPalette("tokyo night")
+func LookupPalette(name string) (Palette, bool) {
+	for _, palette := range builtInPalettes() {
+		if strings.EqualFold(palette.Name, name) {
+			return palette, true
+		}
+	}
+
+	return Palette{}, false
+}
+
+// PaletteNames returns the names of the built-in palettes.
+func PaletteNames() []string {
+	palettes := builtInPalettes()
+	names := make([]string, len(palettes))
+	for index, palette := range palettes {
+		names[index] = palette.Name
+	}
+
+	return names
+}
+
+// hexColor returns the color of the 0xrrggbb value.
+func hexColor(rgb uint32) Color {
+	return RGB(byte(rgb>>16), byte(rgb>>8), byte(rgb))
+}
+
+// hexColors returns the ANSI colors of the 0xrrggbb values.
+func hexColors(rgb ...uint32) [16]Color {
+	var colors [16]Color
+	for index := range colors {
+		colors[index] = hexColor(rgb[index])
+	}
+
+	return colors
+}
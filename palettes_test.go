@@ -0,0 +1,88 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuiltInPalettes(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]bool{}
+	for _, palette := range builtInPalettes() {
+		assert.NotEmpty(t, palette.Name)
+		assert.False(t, names[palette.Name], "Should have unique names, %q is duplicated.", palette.Name)
+		names[palette.Name] = true
+
+		for index, color := range palette.ANSI {
+			assert.NotNil(t, color, "Should define the %s color %d.", palette.Name, index)
+		}
+	}
+}
+
+func TestBuiltInPalettesReadable(t *testing.T) {
+	t.Parallel()
+
+	for _, palette := range builtInPalettes() {
+		background := palette.DefaultBackground()
+
+		for role, style := range palette.Theme() {
+			assert.NotEqual(t, background.Hex(), style.Foreground.Hex(), "Should not draw the %s %s role on its background.", palette.Name, role)
+			assert.True(
+				t,
+				contrastRatio(style.Foreground, background) >= minimumMutedContrast,
+				"Should contrast the %s %s role with its background.", palette.Name, role,
+			)
+		}
+
+		gray, _ := NewColorable(nil).SetPalette(palette).NamedColor("gray")
+		assert.True(t, contrastRatio(gray, background) >= minimumMutedContrast, "Should contrast the %s gray with its background.", palette.Name)
+	}
+}
+
+func TestLookupPalette(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		name     string
+		expected Palette
+		found    bool
+	}{
+		{
+			id:       "Should find the palette by its name.",
+			name:     "Catppuccin Mocha",
+			expected: CatppuccinMochaPalette,
+			found:    true,
+		},
+		{
+			id:       "Should ignore the case.",
+			name:     "tokyo night",
+			expected: TokyoNightPalette,
+			found:    true,
+		},
+		{
+			id:   "Should not find an unknown palette.",
+			name: "unknown",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			palette, found := LookupPalette(testCase.name)
+
+			assert.Equal(t, testCase.found, found)
+			assert.Equal(t, testCase.expected, palette)
+		})
+	}
+}
+
+func TestPaletteNames(t *testing.T) {
+	t.Parallel()
+
+	names := PaletteNames()
+
+	assert.Len(t, names, len(builtInPalettes()))
+	assert.Contains(t, names, "Solarized Dark")
+	assert.Contains(t, names, "Windows Console")
+}
@@ -0,0 +1,271 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Segment of a text sharing the same style.
+	Segment struct {
+		Text  string
+		Style Style
+	}
+
+	// Parser decodes a styled text back into segments, as the inverse of Colorable.Sprint(),
+	// it keeps the style and the partial sequences between the consecutive Parse() calls.
+	Parser struct {
+		palette  Palette
+		scanner  sequenceScanner
+		style    Style
+		segments []Segment
+		// text of the pending segment, having the textStyle.
+		text      strings.Builder
+		textStyle Style
+	}
+)
+
+// maxParameter of the color components and the underline style, larger values are ignored.
+const maxParameter = 255
+
+// Parse decodes a styled text into segments, resolving the indexed colors by the DefaultPalette.
+// e.g.: Parse("\x1b[1mbold\x1b[0m plain") -> [{"bold" Style{Font: Fonts(Bold)}} {" plain" Style{}}]
+func Parse(s string) []Segment {
+	return NewParser(DefaultPalette).Parse(s)
+}
+
+// NewParser allocates and returns a new Parser, resolving the indexed colors by the given palette.
+func NewParser(palette Palette) *Parser {
+	return &Parser{
+		palette: palette,
+	}
+}
+
+// Style returns the style applied at the current position.
+func (p *Parser) Style() Style {
+	return p.style
+}
+
+// Parse decodes s into segments, starting with the style left by the previous call.
+func (p *Parser) Parse(s string) []Segment {
+	p.segments = nil
+	p.scanner.scan([]byte(s), p.appendText, p.applySequence)
+	p.flushText()
+
+	return p.segments
+}
+
+// ParseReader decodes the stream into segments, till the end of the stream.
+func (p *Parser) ParseReader(r io.Reader) ([]Segment, error) {
+	p.segments = nil
+	buffer := make([]byte, 32*1024)
+
+	for {
+		n, err := r.Read(buffer)
+		p.scanner.scan(buffer[:n], p.appendText, p.applySequence)
+
+		if err == io.EOF {
+			p.flushText()
+
+			return p.segments, nil
+		}
+
+		if err != nil {
+			p.flushText()
+
+			return p.segments, err
+		}
+	}
+}
+
+// appendText appends the text to the pending segment if they share the same style.
+func (p *Parser) appendText(text []byte) {
+	if p.text.Len() > 0 && !p.textStyle.Equals(p.style) {
+		p.flushText()
+	}
+
+	p.textStyle = p.style
+	p.text.Write(text)
+}
+
+// flushText appends the pending text as a segment.
+func (p *Parser) flushText() {
+	if p.text.Len() == 0 {
+		return
+	}
+
+	p.segments = append(p.segments, Segment{Text: p.text.String(), Style: p.textStyle})
+	p.text.Reset()
+}
+
+// applySequence applies the SGR and OSC 8 sequences to the style, and ignores the others.
+func (p *Parser) applySequence(sequence []byte) {
+	switch {
+	case len(sequence) > 2 && sequence[1] == csiIntroducer && sequence[len(sequence)-1] == sgrSuffix:
+		parameters := sequence[2 : len(sequence)-1]
+		if len(parameters) > 0 && parameters[0] >= '<' && parameters[0] <= '?' {
+			// Private sequences, e.g. \x1b[>4;2m of the modifyOtherKeys.
+			return
+		}
+		p.applySGR(parseParameters(parameters))
+	case bytes.HasPrefix(sequence, []byte(hyperlinkPrefix)):
+		p.style.Link = parseLink(sequence[len(hyperlinkPrefix):])
+	}
+}
+
+// applySGR applies the SGR parameters, each as a list of its ":" separated sub parameters.
+func (p *Parser) applySGR(parameters [][]int) {
+	for index := 0; index < len(parameters); index++ {
+		parameter := parameters[index]
+
+		switch code := parameter[0]; {
+		case code == 0:
+			p.style = Style{Link: p.style.Link}
+		case code == int(Underline) && len(parameter) > 1:
+			if parameter[1] < 0 || parameter[1] > maxParameter {
+				continue
+			}
+			p.style.Font = p.style.Font.Clear(Underline, DoublyUnderlined)
+			p.style.UnderlineStyle = UnderlineStyle(parameter[1])
+		case code >= int(AlternateFont1) && code <= int(AlternateFont9):
+			p.style.Font = p.style.Font.Clear(alternateFonts...).Set(FontEffect(code))
+		case code >= 30 && code <= 37:
+			p.style.Foreground = p.palette.Color(byte(code - 30))
+		case code >= 40 && code <= 47:
+			p.style.Background = p.palette.Color(byte(code - 40))
+		case code >= 90 && code <= 97:
+			p.style.Foreground = p.palette.Color(byte(code - 90 + 8))
+		case code >= 100 && code <= 107:
+			p.style.Background = p.palette.Color(byte(code - 100 + 8))
+		case code == int(foreground) || code == int(background) || code == int(underline):
+			var color Color
+			if color, index = p.parseColor(parameters, index); color != nil {
+				p.setColor(colorMode(code), color)
+			}
+		case code == 39:
+			p.style.Foreground = nil
+		case code == 49:
+			p.style.Background = nil
+		case code == 59:
+			p.style.UnderlineColor = nil
+		case FontEffect(code).Capability() != 0 || (code > 0 && code <= int(CrossedOut)):
+			p.style.Font = p.style.Font.Set(FontEffect(code))
+		default:
+			p.applyOff(code)
+		}
+	}
+}
+
+// applyOff clears the font effects turned off by the code, e.g. 22 for Bold and Faint.
+func (p *Parser) applyOff(code int) {
+	for _, attributes := range fontEffects {
+		if attributes.off == code && attributes.effect != Normal {
+			p.style.Font = p.style.Font.Clear(attributes.effect)
+		}
+	}
+
+	if code == Underline.OffCode() {
+		p.style.UnderlineStyle = NoUnderline
+	}
+}
+
+// parseColor parses an extended color, or returns nil if it is invalid, either with ":" sub parameters as in 38:2::255:0:0,
+// or with the following parameters as in 38;5;196. It returns the index of the last used parameter.
+func (p *Parser) parseColor(parameters [][]int, index int) (Color, int) {
+	arguments := parameters[index][1:]
+	if len(parameters[index]) == 1 && index+1 < len(parameters) {
+		count := 0
+		switch parameters[index+1][0] {
+		case 5:
+			count = 2
+		case 2:
+			count = 4
+		}
+
+		for next := index + 1; next < len(parameters) && len(arguments) < count; next++ {
+			arguments = append(arguments, parameters[next][0])
+		}
+		index += len(arguments)
+	}
+
+	for _, argument := range arguments {
+		if argument < 0 || argument > maxParameter {
+			return nil, index
+		}
+	}
+
+	switch {
+	case len(arguments) >= 2 && arguments[0] == 5:
+		return p.palette.Color(byte(arguments[1])), index
+	case len(arguments) >= 5 && arguments[0] == 2:
+		// The color space identifier, as in 38:2:id:r:g:b.
+		arguments = arguments[1:]
+		fallthrough
+	case len(arguments) == 4 && arguments[0] == 2:
+		return RGB(byte(arguments[1]), byte(arguments[2]), byte(arguments[3])), index
+	}
+
+	return nil, index
+}
+
+func (p *Parser) setColor(mode colorMode, color Color) {
+	switch mode {
+	case foreground:
+		p.style.Foreground = color
+	case background:
+		p.style.Background = color
+	case underline:
+		p.style.UnderlineColor = color
+	}
+}
+
+// alternateFonts are mutually exclusive.
+var alternateFonts = []FontEffect{
+	AlternateFont1,
+	AlternateFont2,
+	AlternateFont3,
+	AlternateFont4,
+	AlternateFont5,
+	AlternateFont6,
+	AlternateFont7,
+	AlternateFont8,
+	AlternateFont9,
+}
+
+// parseParameters splits the SGR parameters by ";", and their sub parameters by ":",
+// the omitted values are zeros, as in \x1b[m.
+func parseParameters(parameters []byte) [][]int {
+	parsed := make([][]int, 0, bytes.Count(parameters, []byte{';'})+1)
+
+	for _, parameter := range bytes.Split(parameters, []byte{';'}) {
+		subParameters := bytes.Split(parameter, []byte{':'})
+		values := make([]int, len(subParameters))
+		for index, subParameter := range subParameters {
+			values[index], _ = strconv.Atoi(string(subParameter))
+		}
+		parsed = append(parsed, values)
+	}
+
+	return parsed
+}
+
+// parseLink parses the parameters and the url of an OSC 8 sequence, as in id=1;https://example.com
+func parseLink(sequence []byte) Link {
+	sequence = bytes.TrimSuffix(bytes.TrimSuffix(sequence, []byte{bell}), []byte(hyperlinkTerminator))
+
+	separator := bytes.IndexByte(sequence, ';')
+	if separator < 0 {
+		return Link{}
+	}
+
+	link := Link{URL: string(sequence[separator+1:])}
+	for _, parameter := range bytes.Split(sequence[:separator], []byte{':'}) {
+		if bytes.HasPrefix(parameter, []byte("id=")) {
+			link.ID = string(parameter[len("id="):])
+		}
+	}
+
+	return link
+}
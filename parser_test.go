@@ -0,0 +1,162 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected []Segment
+	}{
+		{
+			id:       "Should return no segments for an empty text.",
+			input:    "",
+			expected: nil,
+		},
+		{
+			id:       "Should return a plain segment for a plain text.",
+			input:    "plain",
+			expected: []Segment{{Text: "plain"}},
+		},
+		{
+			id:    "Should parse the font effects and reset them.",
+			input: "\x1b[1;3mbold italic\x1b[0m plain",
+			expected: []Segment{
+				{Text: "bold italic", Style: Style{Font: Fonts(Bold, Italic)}},
+				{Text: " plain"},
+			},
+		},
+		{
+			id:    "Should parse the off codes.",
+			input: "\x1b[1;2;4mstyled\x1b[22mno bold\x1b[24mnone",
+			expected: []Segment{
+				{Text: "styled", Style: Style{Font: Fonts(Bold, Faint, Underline)}},
+				{Text: "no bold", Style: Style{Font: Fonts(Underline)}},
+				{Text: "none", Style: Style{}},
+			},
+		},
+		{
+			id:    "Should parse the 16 colors by the palette.",
+			input: "\x1b[31;102mred on green\x1b[39;49m",
+			expected: []Segment{
+				{Text: "red on green", Style: Style{Foreground: RGB(0xcd, 0x00, 0x00), Background: RGB(0x00, 0xff, 0x00)}},
+			},
+		},
+		{
+			id:    "Should parse the 256 colors.",
+			input: "\x1b[38;5;196mred\x1b[48;5;244mgray",
+			expected: []Segment{
+				{Text: "red", Style: Style{Foreground: RGB(0xff, 0x00, 0x00)}},
+				{Text: "gray", Style: Style{Foreground: RGB(0xff, 0x00, 0x00), Background: RGB(0x80, 0x80, 0x80)}},
+			},
+		},
+		{
+			id:    "Should parse the true colors with the following parameters.",
+			input: "\x1b[38;2;1;2;3;1mcolored",
+			expected: []Segment{
+				{Text: "colored", Style: Style{Foreground: RGB(1, 2, 3), Font: Fonts(Bold)}},
+			},
+		},
+		{
+			id:    "Should parse the true colors with the sub parameters.",
+			input: "\x1b[38:2::1:2:3mspace\x1b[48:2:4:5:6mno space",
+			expected: []Segment{
+				{Text: "space", Style: Style{Foreground: RGB(1, 2, 3)}},
+				{Text: "no space", Style: Style{Foreground: RGB(1, 2, 3), Background: RGB(4, 5, 6)}},
+			},
+		},
+		{
+			id:    "Should parse the underline styles and colors.",
+			input: "\x1b[4:3;58;2;255;0;0mcurly\x1b[4:0;59mnone",
+			expected: []Segment{
+				{Text: "curly", Style: Style{UnderlineStyle: CurlyUnderline, UnderlineColor: RGB(255, 0, 0)}},
+				{Text: "none", Style: Style{}},
+			},
+		},
+		{
+			id:    "Should ignore the out of range color components and underline styles.",
+			input: "\x1b[31m\x1b[38;2;256;0;0mred, \x1b[48;5;300;4:256mplain background, \x1b[58:2::1:2:1000mno underline color",
+			expected: []Segment{
+				{Text: "red, plain background, no underline color", Style: Style{Foreground: RGB(205, 0, 0)}},
+			},
+		},
+		{
+			id:    "Should switch between the alternate fonts.",
+			input: "\x1b[11mfirst\x1b[12msecond\x1b[10mprimary",
+			expected: []Segment{
+				{Text: "first", Style: Style{Font: Fonts(AlternateFont1)}},
+				{Text: "second", Style: Style{Font: Fonts(AlternateFont2)}},
+				{Text: "primary", Style: Style{}},
+			},
+		},
+		{
+			id:    "Should parse the hyperlinks.",
+			input: "\x1b]8;id=1;https://example.com\x1b\\\x1b[1mlink\x1b[0m\x1b]8;;\a plain",
+			expected: []Segment{
+				{Text: "link", Style: Style{Font: Fonts(Bold), Link: Link{URL: "https://example.com", ID: "1"}}},
+				{Text: " plain"},
+			},
+		},
+		{
+			id:    "Should ignore the non SGR and the private sequences.",
+			input: "\x1b[2J\x1b[1mcleared\x1b[K\x1b[>4;2m text",
+			expected: []Segment{
+				{Text: "cleared text", Style: Style{Font: Fonts(Bold)}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Parse(testCase.input))
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(nil).EnableColor()
+
+	styles := []Style{
+		{Foreground: RGB(255, 0, 0), Font: Fonts(Bold, Underline)},
+		{Background: RGB(1, 2, 3), Font: Fonts(Italic, CrossedOut)},
+		{UnderlineStyle: DashedUnderline, UnderlineColor: RGB(0, 0, 255)},
+		{Foreground: RGB(9, 9, 9), Link: Link{URL: "https://example.com"}},
+	}
+
+	for _, style := range styles {
+		segments := Parse(colorized.Sprint(style, "text") + " plain")
+
+		assert.Equal(t, []Segment{{Text: "text", Style: style}, {Text: " plain"}}, segments)
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	t.Parallel()
+
+	parser := NewParser(DefaultPalette)
+	reader := iotest.OneByteReader(strings.NewReader("\x1b[38;5;21mblue\x1b[0m \x1b[3mitalic"))
+
+	segments, err := parser.ParseReader(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{
+		{Text: "blue", Style: Style{Foreground: RGB(0x00, 0x00, 0xff)}},
+		{Text: " "},
+		{Text: "italic", Style: Style{Font: Fonts(Italic)}},
+	}, segments)
+	assert.Equal(t, Style{Font: Fonts(Italic)}, parser.Style())
+
+	segments = parser.Parse(" still\x1b[23m")
+
+	assert.Equal(t, []Segment{{Text: " still", Style: Style{Font: Fonts(Italic)}}}, segments)
+	assert.Equal(t, Style{}, parser.Style())
+}
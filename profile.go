@@ -0,0 +1,177 @@
+package colorize
+
+import (
+	"github.com/mattn/go-isatty"
+	"io"
+	"os"
+	"strings"
+)
+
+type (
+	// Capability flag of a feature that is not supported by every terminal.
+	Capability uint32
+
+	// Profile is the set of capabilities supported by the output terminal,
+	// styles are degraded to what the profile is able to render.
+	Profile Capability
+)
+
+// Capabilities beyond the basic SGR attributes.
+const (
+	// StyledUnderline curly, double, dotted and dashed underlines (SGR 4:x).
+	StyledUnderline Capability = 1 << iota
+	// ColoredUnderline underline color, independent of the foreground (SGR 58).
+	ColoredUnderline
+	// DoublyUnderline the DoublyUnderlined font effect (SGR 21).
+	DoublyUnderline
+	// FramedText the Framed and Encircled font effects (SGR 51, 52).
+	FramedText
+	// OverlinedText the Overlined font effect (SGR 53).
+	OverlinedText
+	// IdeogramText the ideogram font effects (SGR 60-64).
+	IdeogramText
+	// ScriptText the Superscript and Subscript font effects (SGR 73, 74).
+	ScriptText
+	// AlternateFonts the alternative fonts (SGR 11-19).
+	AlternateFonts
+	// Hyperlinks the OSC 8 hyperlinks, falling back to "text (url)" otherwise.
+	Hyperlinks
+)
+
+const (
+	// BasicProfile supports only the basic SGR attributes.
+	BasicProfile = Profile(0)
+	// ExtendedProfile supports all the known capabilities,
+	// however no single terminal is known to render all of them.
+	ExtendedProfile = Profile(
+		StyledUnderline |
+			ColoredUnderline |
+			DoublyUnderline |
+			FramedText |
+			OverlinedText |
+			IdeogramText |
+			ScriptText |
+			AlternateFonts |
+			Hyperlinks,
+	)
+)
+
+// DefaultProfile is the profile assigned to the newly created Colorable instances,
+// detected from the stdout's file descriptor and the environment, see DetectProfile().
+var DefaultProfile = DetectProfile(os.Stdout)
+
+// modernProfile of the terminals known to render the styled and colored underlines, overlines and hyperlinks.
+var modernProfile = BasicProfile.With(StyledUnderline, ColoredUnderline, DoublyUnderline, OverlinedText, Hyperlinks)
+
+// DetectProfile returns the profile of the terminal written to by the output,
+// as told by the TERM, COLORTERM, TERM_PROGRAM and WT_SESSION environment variables,
+// falling back to the basic profile when the output is not a terminal, or the terminal is not known.
+// e.g.: colorized := NewColorable(file).SetProfile(DetectProfile(file))
+func DetectProfile(output io.Writer) Profile {
+	if !isTerminal(output) {
+		return BasicProfile
+	}
+
+	return environmentProfile()
+}
+
+// isTerminal returns true if the writer is a terminal file.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(interface{ Fd() uintptr })
+
+	return ok && (isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd()))
+}
+
+// environmentProfile returns the profile of the terminal told by the environment variables.
+func environmentProfile() Profile {
+	term := os.Getenv("TERM")
+	if term == "dumb" {
+		return BasicProfile
+	}
+
+	if os.Getenv("WT_SESSION") != "" {
+		return modernProfile
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "WezTerm", "ghostty":
+		return modernProfile.With(FramedText, ScriptText)
+	case "iTerm.app", "vscode", "Hyper", "Tabby", "rio":
+		return modernProfile
+	case "Apple_Terminal":
+		return BasicProfile
+	}
+
+	switch {
+	case strings.Contains(term, "wezterm"), strings.Contains(term, "ghostty"):
+		return modernProfile.With(FramedText, ScriptText)
+	case strings.Contains(term, "kitty"),
+		strings.HasPrefix(term, "alacritty"),
+		strings.HasPrefix(term, "foot"),
+		strings.HasPrefix(term, "contour"):
+		return modernProfile
+	}
+
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return modernProfile
+	}
+
+	return BasicProfile
+}
+
+// Supports returns true if all the given capabilities are part of the profile.
+func (p Profile) Supports(capability Capability) bool {
+	return Capability(p)&capability == capability
+}
+
+// With returns a copy of the profile including the given capabilities.
+func (p Profile) With(capabilities ...Capability) Profile {
+	for _, capability := range capabilities {
+		p |= Profile(capability)
+	}
+
+	return p
+}
+
+// Without returns a copy of the profile excluding the given capabilities.
+func (p Profile) Without(capabilities ...Capability) Profile {
+	for _, capability := range capabilities {
+		p &^= Profile(capability)
+	}
+
+	return p
+}
+
+// apply degrades the style to what the profile is able to render.
+func (p Profile) apply(style Style) Style {
+	style.Font = p.applyFont(style.Font)
+
+	if style.UnderlineStyle != NoUnderline && !p.Supports(StyledUnderline) {
+		style.Font = style.Font.Set(Underline)
+		style.UnderlineStyle = NoUnderline
+	}
+
+	if style.UnderlineColor != nil && !p.Supports(ColoredUnderline) {
+		style.UnderlineColor = nil
+	}
+
+	return style
+}
+
+// applyFont drops the font effects that the profile is not able to render,
+// a double underline falls back to a plain one.
+func (p Profile) applyFont(fonts FontEffects) FontEffects {
+	for _, attributes := range fontEffects {
+		if !fonts.Has(attributes.effect) || p.Supports(attributes.capability) {
+			continue
+		}
+
+		fonts = fonts.Clear(attributes.effect)
+		if attributes.effect == DoublyUnderlined {
+			fonts = fonts.Set(Underline)
+		}
+	}
+
+	return fonts
+}
@@ -0,0 +1,232 @@
+package colorize
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestProfileSupports(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id         string
+		input      Profile
+		capability Capability
+		expected   bool
+	}{
+		{
+			id:         "Should not support styled underline with the basic profile.",
+			input:      BasicProfile,
+			capability: StyledUnderline,
+			expected:   false,
+		},
+		{
+			id:         "Should support styled underline and underline color with the extended profile.",
+			input:      ExtendedProfile,
+			capability: StyledUnderline | ColoredUnderline,
+			expected:   true,
+		},
+		{
+			id:         "Should support an added capability.",
+			input:      BasicProfile.With(ColoredUnderline),
+			capability: ColoredUnderline,
+			expected:   true,
+		},
+		{
+			id:         "Should not support a removed capability.",
+			input:      ExtendedProfile.Without(ColoredUnderline),
+			capability: ColoredUnderline,
+			expected:   false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.input.Supports(testCase.capability))
+		})
+	}
+}
+
+func TestProfileDegradation(t *testing.T) {
+	t.Parallel()
+
+	style := Style{
+		Foreground:     RGB(255, 0, 0),
+		UnderlineStyle: CurlyUnderline,
+		UnderlineColor: RGB(0, 0, 255),
+	}
+	testCases := []struct {
+		id       string
+		input    Profile
+		expected string
+	}{
+		{
+			id:       "Should output curly underline and underline color with the extended profile.",
+			input:    ExtendedProfile,
+			expected: "\x1b[38;2;255;0;0;4:3;58;2;0;0;255mtypo\x1b[0m",
+		},
+		{
+			id:       "Should fall back to a plain colored underline without styled underline.",
+			input:    ExtendedProfile.Without(StyledUnderline),
+			expected: "\x1b[38;2;255;0;0;4;58;2;0;0;255mtypo\x1b[0m",
+		},
+		{
+			id:       "Should fall back to a plain underline with the basic profile.",
+			input:    BasicProfile,
+			expected: "\x1b[38;2;255;0;0;4mtypo\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			colorized := NewColorable(os.Stdout).SetProfile(testCase.input)
+
+			assert.Equal(t, testCase.input, colorized.Profile())
+			assert.Equal(
+				t,
+				fmt.Sprintf("%q", testCase.expected),
+				fmt.Sprintf("%q", colorized.Sprint(style, "typo")),
+			)
+		})
+	}
+}
+
+func TestProfileFontDegradation(t *testing.T) {
+	t.Parallel()
+
+	style := Style{
+		Font: Fonts(Bold, DoublyUnderlined, Overlined, Superscript),
+	}
+	testCases := []struct {
+		id       string
+		input    Profile
+		expected string
+	}{
+		{
+			id:       "Should output all the font effects with the extended profile.",
+			input:    ExtendedProfile,
+			expected: "\x1b[1;21;53;73mnote\x1b[0m",
+		},
+		{
+			id:       "Should drop the overlined effect without its capability.",
+			input:    ExtendedProfile.Without(OverlinedText),
+			expected: "\x1b[1;21;73mnote\x1b[0m",
+		},
+		{
+			id:       "Should fall back to a plain underline with the basic profile.",
+			input:    BasicProfile,
+			expected: "\x1b[1;4mnote\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			colorized := NewColorable(os.Stdout).SetProfile(testCase.input)
+
+			assert.Equal(
+				t,
+				fmt.Sprintf("%q", testCase.expected),
+				fmt.Sprintf("%q", colorized.Sprint(style, "note")),
+			)
+			assert.Equal(t, Fonts(Bold, DoublyUnderlined, Overlined, Superscript), style.Font)
+		})
+	}
+}
+
+func TestDetectProfile(t *testing.T) {
+	testCases := []struct {
+		id          string
+		environment map[string]string
+		expected    Profile
+	}{
+		{
+			id:          "Should fall back to the basic profile for an unknown terminal.",
+			environment: map[string]string{"TERM": "xterm-256color"},
+			expected:    BasicProfile,
+		},
+		{
+			id:          "Should detect the basic profile for a dumb terminal.",
+			environment: map[string]string{"TERM": "dumb", "COLORTERM": "truecolor"},
+			expected:    BasicProfile,
+		},
+		{
+			id:          "Should detect a modern terminal by COLORTERM.",
+			environment: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"},
+			expected:    modernProfile,
+		},
+		{
+			id:          "Should detect kitty by TERM.",
+			environment: map[string]string{"TERM": "xterm-kitty"},
+			expected:    modernProfile,
+		},
+		{
+			id:          "Should detect WezTerm by TERM_PROGRAM.",
+			environment: map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"},
+			expected:    modernProfile.With(FramedText, ScriptText),
+		},
+		{
+			id:          "Should detect the macOS Terminal by TERM_PROGRAM.",
+			environment: map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "Apple_Terminal", "COLORTERM": "truecolor"},
+			expected:    BasicProfile,
+		},
+		{
+			id:          "Should detect Windows Terminal by WT_SESSION.",
+			environment: map[string]string{"WT_SESSION": "b1e5b5a4-0f3c-4d3e-9d8e-3c2f1a0b9c8d"},
+			expected:    modernProfile,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			restore := setEnvironment(testCase.environment)
+			defer restore()
+
+			assert.Equal(t, testCase.expected, environmentProfile())
+		})
+	}
+}
+
+func TestDetectProfileNonTerminal(t *testing.T) {
+	restore := setEnvironment(map[string]string{"TERM": "xterm-kitty", "COLORTERM": "truecolor"})
+	defer restore()
+
+	devNull, err := os.Open(os.DevNull)
+	assert.NoError(t, err)
+	defer devNull.Close()
+
+	assert.Equal(t, BasicProfile, DetectProfile(&bytes.Buffer{}))
+	assert.Equal(t, BasicProfile, DetectProfile(devNull))
+}
+
+// setEnvironment sets the profile environment variables, unsetting the missing ones,
+// and returns a function restoring them.
+func setEnvironment(environment map[string]string) func() {
+	names := []string{"TERM", "COLORTERM", "TERM_PROGRAM", "WT_SESSION"}
+	previous := map[string]*string{}
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok {
+			previous[name] = &value
+		} else {
+			previous[name] = nil
+		}
+
+		if value, ok := environment[name]; ok {
+			os.Setenv(name, value)
+		} else {
+			os.Unsetenv(name)
+		}
+	}
+
+	return func() {
+		for name, value := range previous {
+			if value == nil {
+				os.Unsetenv(name)
+				continue
+			}
+			os.Setenv(name, *value)
+		}
+	}
+}
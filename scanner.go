@@ -0,0 +1,126 @@
+package colorize
+
+type (
+	scannerState byte
+
+	// sequenceScanner splits a stream into text and escape sequences (CSI, OSC, DCS...),
+	// keeping its state between the consecutive scans, so a sequence might span multiple writes.
+	sequenceScanner struct {
+		state    scannerState
+		sequence []byte
+	}
+)
+
+const (
+	escape        = 0x1b
+	bell          = 0x07
+	stringEscape  = '\\'
+	csiIntroducer = '['
+	oscIntroducer = ']'
+
+	// maxSequenceSize of a sequence, a longer one is taken as unterminated,
+	// its introducer is dropped and its remaining bytes are scanned again as text.
+	maxSequenceSize = 4096
+)
+
+const (
+	scanningText scannerState = iota
+	scanningEscape
+	scanningIntermediate
+	scanningCSI
+	scanningString
+	scanningStringEscape
+)
+
+// scan calls text for the consecutive text bytes, and sequence for each complete escape sequence of p.
+// The passed slices are only valid till the callback returns.
+func (ss *sequenceScanner) scan(p []byte, text func([]byte), sequence func([]byte)) {
+	start := 0
+	for index := 0; index < len(p); index++ {
+		char := p[index]
+
+		if ss.state == scanningText {
+			if char != escape {
+				continue
+			}
+
+			if index > start {
+				text(p[start:index])
+			}
+			ss.state = scanningEscape
+			ss.sequence = append(ss.sequence[:0], char)
+
+			continue
+		}
+
+		if len(ss.sequence) == maxSequenceSize {
+			ss.abort(text, sequence)
+			// The current byte is scanned again, in the state left by the aborted sequence.
+			start = index
+			index--
+
+			continue
+		}
+		ss.sequence = append(ss.sequence, char)
+
+		if ss.advance(char) {
+			sequence(ss.sequence)
+			ss.state = scanningText
+			ss.sequence = ss.sequence[:0]
+		}
+		start = index + 1
+	}
+
+	if ss.state == scanningText && start < len(p) {
+		text(p[start:])
+	}
+}
+
+// abort drops the escape and the introducer of the pending sequence, and scans its remaining bytes as text.
+func (ss *sequenceScanner) abort(text func([]byte), sequence func([]byte)) {
+	remaining := append([]byte(nil), ss.sequence[2:]...)
+	ss.state = scanningText
+	ss.sequence = ss.sequence[:0]
+
+	ss.scan(remaining, text, sequence)
+}
+
+// advance moves to the next state, and returns true when the sequence is complete.
+func (ss *sequenceScanner) advance(char byte) bool {
+	switch ss.state {
+	case scanningEscape:
+		switch {
+		case char == csiIntroducer:
+			ss.state = scanningCSI
+		case char == oscIntroducer || char == 'P' || char == 'X' || char == '^' || char == '_':
+			// OSC, DCS, SOS, PM and APC are terminated by ST, or BEL for OSC.
+			ss.state = scanningString
+		case char >= 0x20 && char <= 0x2f:
+			ss.state = scanningIntermediate
+		case char == escape:
+			ss.sequence = ss.sequence[:1]
+		default:
+			return true
+		}
+	case scanningIntermediate:
+		return char < 0x20 || char > 0x2f
+	case scanningCSI:
+		return char >= 0x40 && char <= 0x7e
+	case scanningString:
+		switch char {
+		case bell:
+			return true
+		case escape:
+			ss.state = scanningStringEscape
+		}
+	case scanningStringEscape:
+		if char == stringEscape {
+			return true
+		}
+		if char != escape {
+			ss.state = scanningString
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,646 @@
+package colorize
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	// SchemeFormat of the terminal color scheme files.
+	SchemeFormat int
+
+	// schemeKeys of a scheme format, naming the palette colors, and its name if the format has one.
+	schemeKeys struct {
+		ansi       [16]string
+		foreground string
+		background string
+		cursor     string
+		selection  string
+		name       string
+	}
+)
+
+// Terminal color scheme formats.
+const (
+	// ITerm2Scheme of the iTerm2 .itermcolors plist files.
+	ITerm2Scheme SchemeFormat = iota + 1
+	// WindowsTerminalScheme of the Windows Terminal JSON schemes, or the first one of its settings "schemes".
+	WindowsTerminalScheme
+	// AlacrittyTOMLScheme of the Alacritty TOML configurations.
+	AlacrittyTOMLScheme
+	// AlacrittyYAMLScheme of the Alacritty YAML configurations, prior to Alacritty 0.13.
+	AlacrittyYAMLScheme
+	// KittyScheme of the kitty .conf files.
+	KittyScheme
+	// Base16Scheme of the base16 and base24 YAML schemes.
+	Base16Scheme
+	// XresourcesScheme of the .Xresources files, supporting the #define directives.
+	XresourcesScheme
+	// WezTermScheme of the WezTerm TOML color schemes.
+	WezTermScheme
+	// VSCodeScheme of the VS Code integrated terminal colors, as a "workbench.colorCustomizations" snippet.
+	VSCodeScheme
+)
+
+// ansiNames of the ANSI colors, as named by most of the schemes.
+var ansiNames = [8]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var (
+	iTerm2Keys = schemeKeys{
+		foreground: "Foreground Color",
+		background: "Background Color",
+		cursor:     "Cursor Color",
+		selection:  "Selection Color",
+	}
+
+	windowsTerminalKeys = schemeKeys{
+		foreground: "foreground",
+		background: "background",
+		cursor:     "cursorColor",
+		selection:  "selectionBackground",
+		name:       "name",
+	}
+
+	alacrittyKeys = schemeKeys{
+		foreground: "colors.primary.foreground",
+		background: "colors.primary.background",
+		cursor:     "colors.cursor.cursor",
+		selection:  "colors.selection.background",
+	}
+
+	kittyKeys = schemeKeys{
+		foreground: "foreground",
+		background: "background",
+		cursor:     "cursor",
+		selection:  "selection_background",
+	}
+
+	xresourcesKeys = schemeKeys{
+		foreground: "foreground",
+		background: "background",
+		cursor:     "cursorColor",
+		selection:  "highlightColor",
+	}
+
+	wezTermKeys = schemeKeys{
+		foreground: "colors.foreground",
+		background: "colors.background",
+		cursor:     "colors.cursor_bg",
+		selection:  "colors.selection_bg",
+		name:       "metadata.name",
+	}
+
+	vsCodeKeys = schemeKeys{
+		foreground: "terminal.foreground",
+		background: "terminal.background",
+		cursor:     "terminalCursor.foreground",
+		selection:  "terminal.selectionBackground",
+	}
+
+	// base16Keys follow the base16-shell mapping, the bright colors repeat the normal ones.
+	base16Keys = schemeKeys{
+		ansi: [16]string{
+			"base00", "base08", "base0b", "base0a", "base0d", "base0e", "base0c", "base05",
+			"base03", "base08", "base0b", "base0a", "base0d", "base0e", "base0c", "base07",
+		},
+		foreground: "base05",
+		background: "base00",
+		cursor:     "base05",
+		selection:  "base02",
+		name:       "scheme",
+	}
+
+	// base24Keys follow the base24 mapping, having distinct bright colors.
+	base24Keys = schemeKeys{
+		ansi: [16]string{
+			"base00", "base08", "base0b", "base0a", "base0d", "base0e", "base0c", "base06",
+			"base02", "base12", "base14", "base13", "base16", "base17", "base15", "base07",
+		},
+		foreground: "base05",
+		background: "base00",
+		cursor:     "base05",
+		selection:  "base02",
+		name:       "scheme",
+	}
+)
+
+func init() {
+	for index, name := range ansiNames {
+		brightName := "bright" + strings.ToUpper(name[:1]) + name[1:]
+		windowsTerminalName, brightWindowsTerminalName := name, brightName
+		if name == "magenta" {
+			windowsTerminalName, brightWindowsTerminalName = "purple", "brightPurple"
+		}
+
+		iTerm2Keys.ansi[index] = fmt.Sprintf("Ansi %d Color", index)
+		iTerm2Keys.ansi[index+8] = fmt.Sprintf("Ansi %d Color", index+8)
+		windowsTerminalKeys.ansi[index] = windowsTerminalName
+		windowsTerminalKeys.ansi[index+8] = brightWindowsTerminalName
+		alacrittyKeys.ansi[index] = "colors.normal." + name
+		alacrittyKeys.ansi[index+8] = "colors.bright." + name
+		kittyKeys.ansi[index] = "color" + strconv.Itoa(index)
+		kittyKeys.ansi[index+8] = "color" + strconv.Itoa(index+8)
+		xresourcesKeys.ansi[index] = "color" + strconv.Itoa(index)
+		xresourcesKeys.ansi[index+8] = "color" + strconv.Itoa(index+8)
+		wezTermKeys.ansi[index] = "colors.ansi." + strconv.Itoa(index)
+		wezTermKeys.ansi[index+8] = "colors.brights." + strconv.Itoa(index)
+		vsCodeKeys.ansi[index] = "terminal.ansi" + strings.ToUpper(name[:1]) + name[1:]
+		vsCodeKeys.ansi[index+8] = "terminal.ansi" + strings.ToUpper(brightName[:1]) + brightName[1:]
+	}
+}
+
+// ParsePalette reads a terminal color scheme of the given format into a palette,
+// the colors missing from the scheme are left nil, and resolved by the xterm ones.
+// e.g.: palette, err := ParsePalette(file, ITerm2Scheme)
+func ParsePalette(r io.Reader, format SchemeFormat) (Palette, error) {
+	var (
+		values map[string]string
+		keys   schemeKeys
+		err    error
+	)
+
+	switch format {
+	case ITerm2Scheme:
+		values, err = readITerm2(r)
+		keys = iTerm2Keys
+	case WindowsTerminalScheme:
+		values, err = readWindowsTerminal(r)
+		keys = windowsTerminalKeys
+	case AlacrittyTOMLScheme:
+		values, err = readTOML(r)
+		keys = alacrittyKeys
+	case AlacrittyYAMLScheme:
+		values, err = readYAML(r)
+		keys = alacrittyKeys
+	case KittyScheme:
+		values, err = readKitty(r)
+		keys = kittyKeys
+	case Base16Scheme:
+		values, err = readBase16(r)
+		keys = base16Keys
+		if _, ok := values["base12"]; ok {
+			keys = base24Keys
+		}
+	case XresourcesScheme:
+		values, err = readXresources(r)
+		keys = xresourcesKeys
+	case WezTermScheme:
+		values, err = readTOML(r)
+		keys = wezTermKeys
+	case VSCodeScheme:
+		values, err = readVSCode(r)
+		keys = vsCodeKeys
+	default:
+		return Palette{}, fmt.Errorf("palette: unknown scheme format %d", format)
+	}
+
+	if err != nil {
+		return Palette{}, fmt.Errorf("palette: %v", err)
+	}
+
+	return keys.palette(values)
+}
+
+// palette resolves the colors of the named values, the optional cursor and selection colors
+// are left unset if they are not valid colors, as in the "cursor none" of kitty.
+func (keys schemeKeys) palette(values map[string]string) (Palette, error) {
+	palette := Palette{}
+	found := false
+
+	resolve := func(key string, optional bool) (Color, error) {
+		value, ok := values[key]
+		if !ok || key == "" {
+			return nil, nil
+		}
+
+		color, err := parseSchemeColor(value)
+		switch {
+		case err != nil && optional:
+			return nil, nil
+		case err != nil:
+			return nil, fmt.Errorf("palette: %s: %v", key, err)
+		}
+		found = true
+
+		return color, nil
+	}
+
+	var err error
+	for index, key := range keys.ansi {
+		if palette.ANSI[index], err = resolve(key, false); err != nil {
+			return Palette{}, err
+		}
+	}
+	if palette.Foreground, err = resolve(keys.foreground, false); err != nil {
+		return Palette{}, err
+	}
+	if palette.Background, err = resolve(keys.background, false); err != nil {
+		return Palette{}, err
+	}
+	palette.Cursor, _ = resolve(keys.cursor, true)
+	palette.Selection, _ = resolve(keys.selection, true)
+
+	if !found {
+		return Palette{}, fmt.Errorf("palette: no colors found")
+	}
+	palette.Name = values[keys.name]
+
+	return palette, nil
+}
+
+// parseSchemeColor parses the colors as #rrggbb, #rgb, 0xrrggbb, rrggbb or the X11 rgb:rr/gg/bb.
+func parseSchemeColor(value string) (Color, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "rgb:") {
+		parts := strings.Split(value[len("rgb:"):], "/")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid color %q", value)
+		}
+
+		var rgb [3]byte
+		for index, part := range parts {
+			component, err := strconv.ParseUint(part, 16, 16)
+			if err != nil || len(part) == 0 || len(part) > 4 {
+				return nil, fmt.Errorf("invalid color %q", value)
+			}
+			maximum := math.Pow(16, float64(len(part))) - 1
+			rgb[index] = byte(math.Round(float64(component) / maximum * 255))
+		}
+
+		return RGB(rgb[0], rgb[1], rgb[2]), nil
+	}
+
+	hexadecimal := strings.TrimPrefix(value, "#")
+	if strings.HasPrefix(hexadecimal, "0x") || strings.HasPrefix(hexadecimal, "0X") {
+		hexadecimal = hexadecimal[2:]
+	}
+	if (len(hexadecimal) != 3 && len(hexadecimal) != 6) || !isHexadecimal(hexadecimal) {
+		return nil, fmt.Errorf("invalid color %q", value)
+	}
+
+	return Hex("#" + hexadecimal)
+}
+
+// readITerm2 reads the colors of the plist root dictionary as #rrggbb values.
+func readITerm2(r io.Reader) (map[string]string, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "dict" {
+			break
+		}
+	}
+
+	root, err := readPlistDict(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(root))
+	for key, value := range root {
+		components, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var rgb [3]byte
+		for index, name := range [3]string{"Red Component", "Green Component", "Blue Component"} {
+			component, err := strconv.ParseFloat(fmt.Sprint(components[name]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid %s", key, strings.ToLower(name))
+			}
+			rgb[index] = byte(math.Round(math.Max(0, math.Min(1, component)) * 255))
+		}
+		values[key] = fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+	}
+
+	return values, nil
+}
+
+// readPlistDict reads the entries of a plist dictionary, the nested dictionaries as maps and the others as strings.
+func readPlistDict(decoder *xml.Decoder) (map[string]interface{}, error) {
+	dict := make(map[string]interface{})
+	key := ""
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case "key":
+				if err = decoder.DecodeElement(&key, &token); err != nil {
+					return nil, err
+				}
+			case "dict":
+				if dict[key], err = readPlistDict(decoder); err != nil {
+					return nil, err
+				}
+			default:
+				var value string
+				if err = decoder.DecodeElement(&value, &token); err != nil {
+					return nil, err
+				}
+				dict[key] = strings.TrimSpace(value)
+			}
+		case xml.EndElement:
+			return dict, nil
+		}
+	}
+}
+
+// readWindowsTerminal reads the string values of the scheme object.
+func readWindowsTerminal(r io.Reader) (map[string]string, error) {
+	var scheme map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&scheme); err != nil {
+		return nil, err
+	}
+
+	if schemes, ok := scheme["schemes"].([]interface{}); ok {
+		if len(schemes) == 0 {
+			return nil, fmt.Errorf("no schemes found")
+		}
+		if scheme, ok = schemes[0].(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("invalid scheme")
+		}
+	}
+
+	return stringValues(scheme), nil
+}
+
+// readVSCode reads the string values of the "workbench.colorCustomizations" object, or of the root one.
+func readVSCode(r io.Reader) (map[string]string, error) {
+	var settings map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&settings); err != nil {
+		return nil, err
+	}
+
+	if customizations, ok := settings["workbench.colorCustomizations"].(map[string]interface{}); ok {
+		settings = customizations
+	}
+
+	return stringValues(settings), nil
+}
+
+// stringValues returns the string values of the JSON object.
+func stringValues(object map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(object))
+	for key, value := range object {
+		if value, ok := value.(string); ok {
+			values[key] = value
+		}
+	}
+
+	return values
+}
+
+// readTOML reads the string values of a TOML document, keyed by their dotted paths, as in "colors.primary.background",
+// and the arrays items by their indexes, as in "colors.ansi.0".
+func readTOML(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	table := ""
+	// arrayKey and array of an array spanning multiple lines.
+	arrayKey, array := "", ""
+
+	err := readLines(r, func(line string) error {
+		line = stripComment(strings.TrimSpace(line))
+
+		switch {
+		case arrayKey != "":
+			if array += line; strings.HasSuffix(line, "]") {
+				readArray(values, arrayKey, array)
+				arrayKey = ""
+			}
+		case line == "":
+		case line[0] == '[':
+			table = strings.TrimSpace(strings.Trim(line, "[]")) + "."
+		default:
+			key, value, ok := cut(line, "=")
+			if !ok {
+				return fmt.Errorf("invalid line %q", line)
+			}
+
+			key, value = table+unquoteValue(key), strings.TrimSpace(value)
+			if !strings.HasPrefix(value, "[") {
+				values[key] = unquoteValue(value)
+
+				return nil
+			}
+
+			if strings.HasSuffix(value, "]") {
+				readArray(values, key, value)
+
+				return nil
+			}
+			arrayKey, array = key, value
+		}
+
+		return nil
+	})
+
+	if err == nil && arrayKey != "" {
+		err = fmt.Errorf("unterminated array %q", arrayKey)
+	}
+
+	return values, err
+}
+
+// readArray reads the items of the TOML array, keyed by their indexes.
+func readArray(values map[string]string, key, array string) {
+	items := strings.Split(strings.TrimSpace(strings.Trim(array, "[]")), ",")
+	index := 0
+	for _, item := range items {
+		if item = unquoteValue(item); item != "" {
+			values[key+"."+strconv.Itoa(index)] = item
+			index++
+		}
+	}
+}
+
+// readYAML reads the scalar values of the YAML mappings, keyed by their dotted paths, as in "colors.primary.background".
+func readYAML(r io.Reader) (map[string]string, error) {
+	type parent struct {
+		indent int
+		path   string
+	}
+
+	values := make(map[string]string)
+	parents := []parent{}
+
+	err := readLines(r, func(line string) error {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '-' || trimmed == "---" {
+			return nil
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+
+		key, value, ok := cut(trimmed, ":")
+		if !ok {
+			return fmt.Errorf("invalid line %q", trimmed)
+		}
+
+		path := unquoteValue(key)
+		if len(parents) > 0 {
+			path = parents[len(parents)-1].path + "." + path
+		}
+
+		if value = unquoteValue(value); value == "" {
+			parents = append(parents, parent{indent: indent, path: path})
+
+			return nil
+		}
+		values[path] = value
+
+		return nil
+	})
+
+	return values, err
+}
+
+// readKitty reads the "name value" lines of the kitty configuration.
+func readKitty(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+
+	err := readLines(r, func(line string) error {
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0][0] != '#' {
+			values[fields[0]] = fields[1]
+		}
+
+		return nil
+	})
+
+	return values, err
+}
+
+// readBase16 reads the baseXX colors, either at the root as in the base16 schemes,
+// or under the "palette" mapping as in the tinted-theming ones, which name the scheme by "name".
+func readBase16(r io.Reader) (map[string]string, error) {
+	values, err := readYAML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := make(map[string]string, len(values))
+	for key, value := range values {
+		colors[strings.ToLower(strings.TrimPrefix(key, "palette."))] = value
+	}
+	if _, ok := colors["scheme"]; !ok && colors["name"] != "" {
+		colors["scheme"] = colors["name"]
+	}
+
+	return colors, nil
+}
+
+// readXresources reads the resources by their last component, as in "*.color0" or "URxvt*foreground",
+// substituting the values named by the #define directives.
+func readXresources(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	defines := make(map[string]string)
+
+	err := readLines(r, func(line string) error {
+		switch line = strings.TrimSpace(line); {
+		case line == "" || line[0] == '!':
+		case strings.HasPrefix(line, "#define"):
+			if fields := strings.Fields(line); len(fields) >= 3 {
+				defines[fields[1]] = fields[2]
+			}
+		case line[0] == '#':
+		default:
+			resource, value, ok := cut(line, ":")
+			if !ok {
+				return fmt.Errorf("invalid line %q", line)
+			}
+
+			name := resource[strings.LastIndexAny(resource, ".*")+1:]
+			value = strings.TrimSpace(value)
+			if defined, ok := defines[value]; ok {
+				value = defined
+			}
+			values[strings.TrimSpace(name)] = value
+		}
+
+		return nil
+	})
+
+	return values, err
+}
+
+// readLines calls f with each line of r, trimmed from the trailing spaces.
+func readLines(r io.Reader, f func(line string) error) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if err := f(line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// stripComment removes the "#" comment of the line, unless it is quoted.
+func stripComment(line string) string {
+	quote := byte(0)
+	for index := 0; index < len(line); index++ {
+		switch char := line[index]; {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '#':
+			return strings.TrimSpace(line[:index])
+		}
+	}
+
+	return line
+}
+
+// unquoteValue trims the value, removing its quotes or its trailing comment.
+func unquoteValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return value
+	}
+
+	if quote := value[0]; quote == '"' || quote == '\'' {
+		if end := strings.IndexByte(value[1:], quote); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+
+	if comment := strings.Index(value, " #"); comment >= 0 {
+		value = value[:comment]
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// cut slices s around the first separator, returning the text before and after it,
+// and false if the separator is not found.
+func cut(s, separator string) (before, after string, found bool) {
+	if index := strings.Index(s, separator); index >= 0 {
+		return s[:index], s[index+len(separator):], true
+	}
+
+	return s, "", false
+}
@@ -0,0 +1,379 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParsePalette(t *testing.T) {
+	t.Parallel()
+
+	withANSI := func(palette Palette, ansi map[byte]Color) Palette {
+		for index, color := range ansi {
+			palette.ANSI[index] = color
+		}
+
+		return palette
+	}
+
+	testCases := []struct {
+		id       string
+		format   SchemeFormat
+		input    string
+		expected Palette
+	}{
+		{
+			id:     "Should parse the iTerm2 plist.",
+			format: ITerm2Scheme,
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Ansi 1 Color</key>
+	<dict>
+		<key>Alpha Component</key>
+		<real>1</real>
+		<key>Blue Component</key>
+		<real>0.18431372549019609</real>
+		<key>Color Space</key>
+		<string>sRGB</string>
+		<key>Green Component</key>
+		<real>0.19607843137254902</real>
+		<key>Red Component</key>
+		<real>0.86274509803921573</real>
+	</dict>
+	<key>Background Color</key>
+	<dict>
+		<key>Blue Component</key>
+		<real>0.21176470588235294</real>
+		<key>Green Component</key>
+		<real>0.16862745098039217</real>
+		<key>Red Component</key>
+		<real>0.0</real>
+	</dict>
+</dict>
+</plist>`,
+			expected: withANSI(Palette{Background: RGB(0x00, 0x2b, 0x36)}, map[byte]Color{ANSIRed: RGB(0xdc, 0x32, 0x2f)}),
+		},
+		{
+			id:     "Should parse the Windows Terminal scheme.",
+			format: WindowsTerminalScheme,
+			input: `{
+				"name": "Campbell",
+				"foreground": "#CCCCCC",
+				"background": "#0C0C0C",
+				"cursorColor": "#FFFFFF",
+				"purple": "#881798",
+				"brightPurple": "#B4009E"
+			}`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0xcc, 0xcc, 0xcc),
+					Background: RGB(0x0c, 0x0c, 0x0c),
+					Cursor:     RGB(0xff, 0xff, 0xff),
+					Name:       "Campbell",
+				},
+				map[byte]Color{
+					ANSIMagenta:       RGB(0x88, 0x17, 0x98),
+					ANSIBrightMagenta: RGB(0xb4, 0x00, 0x9e),
+				},
+			),
+		},
+		{
+			id:     "Should parse the first scheme of the Windows Terminal settings.",
+			format: WindowsTerminalScheme,
+			input:  `{"profiles": {}, "schemes": [{"red": "#C50F1F"}, {"red": "#000000"}]}`,
+			expected: withANSI(Palette{}, map[byte]Color{
+				ANSIRed: RGB(0xc5, 0x0f, 0x1f),
+			}),
+		},
+		{
+			id:     "Should parse the Alacritty TOML configuration.",
+			format: AlacrittyTOMLScheme,
+			input: `# Tomorrow Night
+[colors.primary]
+background = "#1d1f21"
+foreground = '#c5c8c6' # comment
+
+[colors.normal]
+red = "0xcc6666"
+
+[colors.bright]
+white = "#ffffff"
+
+[colors.cursor]
+cursor = "#ffffff"
+text = "#000000"
+`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0xc5, 0xc8, 0xc6),
+					Background: RGB(0x1d, 0x1f, 0x21),
+					Cursor:     RGB(0xff, 0xff, 0xff),
+				},
+				map[byte]Color{
+					ANSIRed:         RGB(0xcc, 0x66, 0x66),
+					ANSIBrightWhite: RGB(0xff, 0xff, 0xff),
+				},
+			),
+		},
+		{
+			id:     "Should parse the Alacritty YAML configuration.",
+			format: AlacrittyYAMLScheme,
+			input: `font:
+  size: 12
+colors:
+  # Default colors
+  primary:
+    background: '#1d1f21'
+    foreground: "#c5c8c6"
+  normal:
+    black:   '0x1d1f21'
+    green:   0xb5bd68
+  bright:
+    blue: '#81a2be'
+window:
+  background: '#000000'
+`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0xc5, 0xc8, 0xc6),
+					Background: RGB(0x1d, 0x1f, 0x21),
+				},
+				map[byte]Color{
+					ANSIBlack:      RGB(0x1d, 0x1f, 0x21),
+					ANSIGreen:      RGB(0xb5, 0xbd, 0x68),
+					ANSIBrightBlue: RGB(0x81, 0xa2, 0xbe),
+				},
+			),
+		},
+		{
+			id:     "Should parse the kitty configuration.",
+			format: KittyScheme,
+			input: `# vim:ft=kitty
+foreground   #dddddd
+background   #000000
+cursor       #cccccc
+color0       #000000
+  color9     #ff5555
+selection_background #fffacd
+`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0xdd, 0xdd, 0xdd),
+					Background: RGB(0, 0, 0),
+					Cursor:     RGB(0xcc, 0xcc, 0xcc),
+					Selection:  RGB(0xff, 0xfa, 0xcd),
+				},
+				map[byte]Color{
+					ANSIBlack:     RGB(0, 0, 0),
+					ANSIBrightRed: RGB(0xff, 0x55, 0x55),
+				},
+			),
+		},
+		{
+			id:     "Should parse the base16 scheme.",
+			format: Base16Scheme,
+			input: `scheme: "Default Dark"
+author: "Chris Kempson (http://chriskempson.com)"
+base00: "181818"
+base03: "585858"
+base05: "d8d8d8"
+base07: "f8f8f8"
+base08: "ab4642" # red
+`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0xd8, 0xd8, 0xd8),
+					Background: RGB(0x18, 0x18, 0x18),
+					Cursor:     RGB(0xd8, 0xd8, 0xd8),
+					Name:       "Default Dark",
+				},
+				map[byte]Color{
+					ANSIBlack:       RGB(0x18, 0x18, 0x18),
+					ANSIRed:         RGB(0xab, 0x46, 0x42),
+					ANSIWhite:       RGB(0xd8, 0xd8, 0xd8),
+					ANSIBrightBlack: RGB(0x58, 0x58, 0x58),
+					ANSIBrightRed:   RGB(0xab, 0x46, 0x42),
+					ANSIBrightWhite: RGB(0xf8, 0xf8, 0xf8),
+				},
+			),
+		},
+		{
+			id:     "Should parse the base24 palette mapping.",
+			format: Base16Scheme,
+			input: `system: "base24"
+name: "Dracula"
+palette:
+  base00: "#282a36"
+  base06: "#f8f8f2"
+  base08: "#ff5555"
+  base12: "#ff6e6e"
+`,
+			expected: withANSI(
+				Palette{
+					Background: RGB(0x28, 0x2a, 0x36),
+					Name:       "Dracula",
+				},
+				map[byte]Color{
+					ANSIBlack:     RGB(0x28, 0x2a, 0x36),
+					ANSIRed:       RGB(0xff, 0x55, 0x55),
+					ANSIWhite:     RGB(0xf8, 0xf8, 0xf2),
+					ANSIBrightRed: RGB(0xff, 0x6e, 0x6e),
+				},
+			),
+		},
+		{
+			id:     "Should parse the Xresources.",
+			format: XresourcesScheme,
+			input: `! Solarized
+#define S_base03 #002b36
+#ifdef COLORS
+*background: S_base03
+#endif
+URxvt*foreground: rgb:83/94/96
+*.cursorColor:    #93a1a1
+*color1:          #dc322f
+`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0x83, 0x94, 0x96),
+					Background: RGB(0x00, 0x2b, 0x36),
+					Cursor:     RGB(0x93, 0xa1, 0xa1),
+				},
+				map[byte]Color{
+					ANSIRed: RGB(0xdc, 0x32, 0x2f),
+				},
+			),
+		},
+		{
+			id:     "Should parse the WezTerm scheme.",
+			format: WezTermScheme,
+			input: `[colors]
+foreground = "#c0caf5"
+background = "#1a1b26"
+selection_bg = "#33467c"
+ansi = ["#15161e", "#f7768e"]
+brights = [
+    "#414868", # bright black
+    "#f7768e",
+]
+
+[metadata]
+name = "Tokyo Night"
+`,
+			expected: withANSI(
+				Palette{
+					Foreground: RGB(0xc0, 0xca, 0xf5),
+					Background: RGB(0x1a, 0x1b, 0x26),
+					Selection:  RGB(0x33, 0x46, 0x7c),
+					Name:       "Tokyo Night",
+				},
+				map[byte]Color{
+					ANSIBlack:       RGB(0x15, 0x16, 0x1e),
+					ANSIRed:         RGB(0xf7, 0x76, 0x8e),
+					ANSIBrightBlack: RGB(0x41, 0x48, 0x68),
+					ANSIBrightRed:   RGB(0xf7, 0x76, 0x8e),
+				},
+			),
+		},
+		{
+			id:     "Should leave the cursor and selection colors of kitty set to none unset.",
+			format: KittyScheme,
+			input: `foreground #c0caf5
+cursor none
+selection_background none
+selection_foreground none
+color1 #f7768e
+`,
+			expected: withANSI(Palette{Foreground: RGB(0xc0, 0xca, 0xf5)}, map[byte]Color{ANSIRed: RGB(0xf7, 0x76, 0x8e)}),
+		},
+		{
+			id:       "Should leave the invalid cursor and selection colors unset.",
+			format:   WindowsTerminalScheme,
+			input:    `{"background": "#1e1e1e", "cursorColor": "auto", "selectionBackground": "#ff55"}`,
+			expected: Palette{Background: RGB(0x1e, 0x1e, 0x1e)},
+		},
+		{
+			id:     "Should parse the VS Code color customizations.",
+			format: VSCodeScheme,
+			input: `{
+				"editor.fontSize": 12,
+				"workbench.colorCustomizations": {
+					"terminal.background": "#1e1e1e",
+					"terminalCursor.foreground": "#aeafad",
+					"terminal.ansiBrightCyan": "#29b8db"
+				}
+			}`,
+			expected: withANSI(
+				Palette{Background: RGB(0x1e, 0x1e, 0x1e), Cursor: RGB(0xae, 0xaf, 0xad)},
+				map[byte]Color{ANSIBrightCyan: RGB(0x29, 0xb8, 0xdb)},
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			palette, err := ParsePalette(strings.NewReader(testCase.input), testCase.format)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, palette)
+		})
+	}
+}
+
+func TestParsePaletteError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		format   SchemeFormat
+		input    string
+		expected string
+	}{
+		{
+			id:       "Should fail on an unknown format.",
+			format:   SchemeFormat(0),
+			expected: "palette: unknown scheme format 0",
+		},
+		{
+			id:       "Should fail on an invalid document.",
+			format:   WindowsTerminalScheme,
+			input:    `{"red": `,
+			expected: "palette: unexpected EOF",
+		},
+		{
+			id:       "Should fail on an invalid color.",
+			format:   KittyScheme,
+			input:    "color1 #ff55",
+			expected: `palette: color1: invalid color "#ff55"`,
+		},
+		{
+			id:       "Should fail on an unterminated array.",
+			format:   WezTermScheme,
+			input:    "[colors]\nansi = [\n\"#000000\",",
+			expected: `palette: unterminated array "colors.ansi"`,
+		},
+		{
+			id:       "Should fail with only the optional colors unset.",
+			format:   KittyScheme,
+			input:    "cursor none",
+			expected: "palette: no colors found",
+		},
+		{
+			id:       "Should fail without colors.",
+			format:   AlacrittyTOMLScheme,
+			input:    "[font]\nsize = 12",
+			expected: "palette: no colors found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			_, err := ParsePalette(strings.NewReader(testCase.input), testCase.format)
+
+			assert.EqualError(t, err, testCase.expected)
+		})
+	}
+}
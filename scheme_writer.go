@@ -0,0 +1,258 @@
+package colorize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// schemeEntry of a written scheme, a named color.
+	schemeEntry struct {
+		key   string
+		color Color
+	}
+)
+
+// defaultSchemeName of the Windows Terminal schemes written from the unnamed palettes, as the name is required.
+const defaultSchemeName = "Colorize"
+
+// WritePalette writes the palette as a terminal color scheme of the given format,
+// the missing ANSI colors are written as the xterm ones, and the missing foreground and background
+// as the ANSI white and black, while the missing cursor and selection colors are left to the terminal defaults.
+// The unnamed palettes are written as "Colorize" to the Windows Terminal schemes, which require a name.
+// The base16 schemes are not supported, as their colors do not map to the terminal ones.
+// e.g.: err := WritePalette(file, brandPalette, KittyScheme)
+func WritePalette(w io.Writer, palette Palette, format SchemeFormat) error {
+	b := &bytes.Buffer{}
+
+	switch format {
+	case ITerm2Scheme:
+		writeITerm2(b, iTerm2Keys.entries(palette))
+	case WindowsTerminalScheme:
+		name := palette.Name
+		if name == "" {
+			name = defaultSchemeName
+		}
+		writeJSONObject(b, "", append([][2]string{{"name", name}}, jsonMembers(windowsTerminalKeys.entries(palette))...))
+		b.WriteByte('\n')
+	case AlacrittyTOMLScheme:
+		writeComment(b, "# ", palette.Name)
+		writeTOMLTables(b, alacrittyKeys.entries(palette))
+	case AlacrittyYAMLScheme:
+		writeComment(b, "# ", palette.Name)
+		writeYAML(b, alacrittyKeys.entries(palette))
+	case KittyScheme:
+		writeComment(b, "# ", palette.Name)
+		for _, entry := range kittyKeys.entries(palette) {
+			fmt.Fprintf(b, "%-20s %s\n", entry.key, entry.color.Hex())
+		}
+	case XresourcesScheme:
+		writeComment(b, "! ", palette.Name)
+		for _, entry := range xresourcesKeys.entries(palette) {
+			fmt.Fprintf(b, "*.%s: %s\n", entry.key, entry.color.Hex())
+		}
+	case WezTermScheme:
+		writeWezTerm(b, palette)
+	case VSCodeScheme:
+		b.WriteString("{\n  \"workbench.colorCustomizations\": ")
+		writeJSONObject(b, "  ", jsonMembers(vsCodeKeys.entries(palette)))
+		b.WriteString("\n}\n")
+	case Base16Scheme:
+		return fmt.Errorf("palette: writing the base16 schemes is not supported")
+	default:
+		return fmt.Errorf("palette: unknown scheme format %d", format)
+	}
+
+	_, err := w.Write(b.Bytes())
+
+	return err
+}
+
+// entries returns the named colors of the palette, skipping the unset cursor and selection colors.
+func (keys schemeKeys) entries(palette Palette) []schemeEntry {
+	entries := make([]schemeEntry, 0, len(keys.ansi)+4)
+	add := func(key string, color Color) {
+		if key != "" && color != nil {
+			entries = append(entries, schemeEntry{key: key, color: color})
+		}
+	}
+
+	add(keys.foreground, palette.DefaultForeground())
+	add(keys.background, palette.DefaultBackground())
+	add(keys.cursor, palette.Cursor)
+	add(keys.selection, palette.Selection)
+	for index, key := range keys.ansi {
+		add(key, palette.Color(byte(index)))
+	}
+
+	return entries
+}
+
+// writeITerm2 writes the entries as a plist dictionary of the color components.
+func writeITerm2(b *bytes.Buffer, entries []schemeEntry) {
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+
+	for _, entry := range entries {
+		fmt.Fprintf(b, "\t<key>%s</key>\n\t<dict>\n", entry.key)
+		b.WriteString("\t\t<key>Alpha Component</key>\n\t\t<real>1</real>\n")
+		for _, component := range []struct {
+			name  string
+			value byte
+		}{
+			{"Blue Component", entry.color.Blue()},
+			{"Green Component", entry.color.Green()},
+			{"Red Component", entry.color.Red()},
+		} {
+			fmt.Fprintf(b, "\t\t<key>%s</key>\n\t\t<real>%s</real>\n",
+				component.name, strconv.FormatFloat(float64(component.value)/255, 'f', -1, 64))
+		}
+		b.WriteString("\t\t<key>Color Space</key>\n\t\t<string>sRGB</string>\n\t</dict>\n")
+	}
+
+	b.WriteString("</dict>\n</plist>\n")
+}
+
+// writeTOMLTables writes the entries grouped by their tables, as in [colors.primary].
+func writeTOMLTables(b *bytes.Buffer, entries []schemeEntry) {
+	table := ""
+	for _, entry := range entries {
+		separator := strings.LastIndexByte(entry.key, '.')
+		if entry.key[:separator] != table {
+			if table != "" {
+				b.WriteByte('\n')
+			}
+			table = entry.key[:separator]
+			fmt.Fprintf(b, "[%s]\n", table)
+		}
+		fmt.Fprintf(b, "%s = %s\n", entry.key[separator+1:], tomlString(entry.color.Hex()))
+	}
+}
+
+// writeYAML writes the entries as nested mappings, by the components of their dotted keys.
+func writeYAML(b *bytes.Buffer, entries []schemeEntry) {
+	var previous []string
+	for _, entry := range entries {
+		path := strings.Split(entry.key, ".")
+
+		common := 0
+		for common < len(previous)-1 && common < len(path)-1 && previous[common] == path[common] {
+			common++
+		}
+		for depth := common; depth < len(path)-1; depth++ {
+			fmt.Fprintf(b, "%s%s:\n", strings.Repeat("  ", depth), path[depth])
+		}
+
+		fmt.Fprintf(b, "%s%s: '%s'\n", strings.Repeat("  ", len(path)-1), path[len(path)-1], entry.color.Hex())
+		previous = path
+	}
+}
+
+// writeWezTerm writes the [colors] table, having the ANSI colors as arrays, and the scheme name in [metadata].
+func writeWezTerm(b *bytes.Buffer, palette Palette) {
+	keys := wezTermKeys
+	keys.ansi = [16]string{}
+
+	b.WriteString("[colors]\n")
+	for _, entry := range keys.entries(palette) {
+		fmt.Fprintf(b, "%s = %s\n", strings.TrimPrefix(entry.key, "colors."), tomlString(entry.color.Hex()))
+	}
+
+	for _, array := range []struct {
+		name  string
+		first byte
+	}{{"ansi", ANSIBlack}, {"brights", ANSIBrightBlack}} {
+		colors := make([]string, 8)
+		for index := range colors {
+			colors[index] = tomlString(palette.Color(array.first + byte(index)).Hex())
+		}
+		fmt.Fprintf(b, "%s = [%s]\n", array.name, strings.Join(colors, ", "))
+	}
+
+	if palette.Name != "" {
+		fmt.Fprintf(b, "\n[metadata]\nname = %s\n", tomlString(palette.Name))
+	}
+}
+
+// writeJSONObject writes the members as a JSON object, indented by two spaces from the given indentation.
+func writeJSONObject(b *bytes.Buffer, indent string, members [][2]string) {
+	b.WriteString("{\n")
+	for index, member := range members {
+		if index > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(b, "%s  %s: %s", indent, jsonString(member[0]), jsonString(member[1]))
+	}
+	fmt.Fprintf(b, "\n%s}", indent)
+}
+
+// writeComment writes the comment line, unless it is empty,
+// replacing its control characters and line separators by spaces, so they do not end the comment.
+func writeComment(b *bytes.Buffer, prefix, comment string) {
+	if comment != "" {
+		fmt.Fprintf(b, "%s%s\n", prefix, strings.Map(func(char rune) rune {
+			if unicode.IsControl(char) || char == '\u2028' || char == '\u2029' {
+				return ' '
+			}
+
+			return char
+		}, comment))
+	}
+}
+
+// jsonMembers returns the entries as JSON object members of hex colors.
+func jsonMembers(entries []schemeEntry) [][2]string {
+	members := make([][2]string, len(entries))
+	for index, entry := range entries {
+		members[index] = [2]string{entry.key, entry.color.Hex()}
+	}
+
+	return members
+}
+
+// jsonString returns s quoted as a JSON string.
+func jsonString(s string) string {
+	quoted, _ := json.Marshal(s)
+
+	return string(quoted)
+}
+
+// tomlString returns s quoted as a TOML basic string.
+func tomlString(s string) string {
+	b := &strings.Builder{}
+	b.WriteByte('"')
+	for _, char := range s {
+		switch char {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(char)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if unicode.IsControl(char) {
+				fmt.Fprintf(b, `\u%04X`, char)
+			} else {
+				b.WriteRune(char)
+			}
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
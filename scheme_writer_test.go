@@ -0,0 +1,199 @@
+package colorize
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWritePalette(t *testing.T) {
+	t.Parallel()
+
+	palette := XtermPalette
+	palette.Name = "Brand <dark>"
+	palette.Foreground = RGB(0xc0, 0xca, 0xf5)
+	palette.Background = RGB(0x1a, 0x1b, 0x26)
+	palette.Cursor = RGB(0xff, 0x9e, 0x64)
+	palette.Selection = RGB(0x33, 0x46, 0x7c)
+	palette.ANSI[ANSIRed] = RGB(0xf7, 0x76, 0x8e)
+
+	testCases := []struct {
+		id       string
+		format   SchemeFormat
+		withName bool
+	}{
+		{id: "Should round trip the iTerm2 plist.", format: ITerm2Scheme},
+		{id: "Should round trip the Windows Terminal scheme.", format: WindowsTerminalScheme, withName: true},
+		{id: "Should round trip the Alacritty TOML configuration.", format: AlacrittyTOMLScheme},
+		{id: "Should round trip the Alacritty YAML configuration.", format: AlacrittyYAMLScheme},
+		{id: "Should round trip the kitty configuration.", format: KittyScheme},
+		{id: "Should round trip the Xresources.", format: XresourcesScheme},
+		{id: "Should round trip the WezTerm scheme.", format: WezTermScheme, withName: true},
+		{id: "Should round trip the VS Code color customizations.", format: VSCodeScheme},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			assert.NoError(t, WritePalette(output, palette, testCase.format))
+
+			expected := palette
+			if !testCase.withName {
+				expected.Name = ""
+			}
+
+			parsed, err := ParsePalette(output, testCase.format)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, parsed)
+		})
+	}
+}
+
+func TestWritePaletteDocument(t *testing.T) {
+	t.Parallel()
+
+	palette := Palette{Name: "Brand", Foreground: RGB(0xc0, 0xca, 0xf5), Cursor: RGB(0xff, 0x9e, 0x64)}
+
+	testCases := []struct {
+		id       string
+		format   SchemeFormat
+		expected string
+	}{
+		{
+			id:     "Should write the kitty configuration, resolving the missing colors.",
+			format: KittyScheme,
+			expected: `# Brand
+foreground           #c0caf5
+background           #000000
+cursor               #ff9e64
+color0               #000000
+color1               #cd0000
+color2               #00cd00
+color3               #cdcd00
+color4               #0000ee
+color5               #cd00cd
+color6               #00cdcd
+color7               #e5e5e5
+color8               #7f7f7f
+color9               #ff0000
+color10              #00ff00
+color11              #ffff00
+color12              #5c5cff
+color13              #ff00ff
+color14              #00ffff
+color15              #ffffff
+`,
+		},
+		{
+			id:     "Should write the VS Code color customizations.",
+			format: VSCodeScheme,
+			expected: `{
+  "workbench.colorCustomizations": {
+    "terminal.foreground": "#c0caf5",
+    "terminal.background": "#000000",
+    "terminalCursor.foreground": "#ff9e64",
+    "terminal.ansiBlack": "#000000",
+    "terminal.ansiRed": "#cd0000",
+    "terminal.ansiGreen": "#00cd00",
+    "terminal.ansiYellow": "#cdcd00",
+    "terminal.ansiBlue": "#0000ee",
+    "terminal.ansiMagenta": "#cd00cd",
+    "terminal.ansiCyan": "#00cdcd",
+    "terminal.ansiWhite": "#e5e5e5",
+    "terminal.ansiBrightBlack": "#7f7f7f",
+    "terminal.ansiBrightRed": "#ff0000",
+    "terminal.ansiBrightGreen": "#00ff00",
+    "terminal.ansiBrightYellow": "#ffff00",
+    "terminal.ansiBrightBlue": "#5c5cff",
+    "terminal.ansiBrightMagenta": "#ff00ff",
+    "terminal.ansiBrightCyan": "#00ffff",
+    "terminal.ansiBrightWhite": "#ffffff"
+  }
+}
+`,
+		},
+		{
+			id:     "Should write the WezTerm scheme.",
+			format: WezTermScheme,
+			expected: `[colors]
+foreground = "#c0caf5"
+background = "#000000"
+cursor_bg = "#ff9e64"
+ansi = ["#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5"]
+brights = ["#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff"]
+
+[metadata]
+name = "Brand"
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+
+			assert.NoError(t, WritePalette(output, palette, testCase.format))
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestWritePaletteName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		name     string
+		format   SchemeFormat
+		expected string
+	}{
+		{
+			id:       "Should replace the control characters of the kitty comment.",
+			name:     "Brand\ncursor #ff0000\r\x1b",
+			format:   KittyScheme,
+			expected: "# Brand cursor #ff0000  \n",
+		},
+		{
+			id:       "Should replace the line breaks of the Xresources comment.",
+			name:     "Brand\n*.background: #ff0000",
+			format:   XresourcesScheme,
+			expected: "! Brand *.background: #ff0000\n",
+		},
+		{
+			id:       "Should replace the line separators of the Alacritty YAML comment.",
+			name:     "Brand\u2028colors:",
+			format:   AlacrittyYAMLScheme,
+			expected: "# Brand colors:\n",
+		},
+		{
+			id:       "Should quote the WezTerm name as a TOML basic string.",
+			name:     "C:\\Brand \"dark\"\n\t\x7f",
+			format:   WezTermScheme,
+			expected: `name = "C:\\Brand \"dark\"\n\t\u007F"` + "\n",
+		},
+		{
+			id:       "Should name the unnamed Windows Terminal scheme.",
+			format:   WindowsTerminalScheme,
+			expected: `  "name": "Colorize",` + "\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			palette := XtermPalette
+			palette.Name = testCase.name
+
+			assert.NoError(t, WritePalette(output, palette, testCase.format))
+			assert.Contains(t, output.String(), testCase.expected)
+		})
+	}
+}
+
+func TestWritePaletteError(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, WritePalette(&bytes.Buffer{}, XtermPalette, Base16Scheme), "palette: writing the base16 schemes is not supported")
+	assert.EqualError(t, WritePalette(&bytes.Buffer{}, XtermPalette, SchemeFormat(0)), "palette: unknown scheme format 0")
+	assert.EqualError(t, WritePalette(failingWriter{}, XtermPalette, KittyScheme), "write failed")
+}
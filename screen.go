@@ -0,0 +1,114 @@
+package colorize
+
+import (
+	"bytes"
+)
+
+type (
+	// cellRun of the consecutive cells of a row, sharing the same style.
+	cellRun struct {
+		column int
+		width  int
+		text   string
+		style  Style
+		wide   bool
+	}
+
+	// screen lays out the styled segments into rows of cells, as a terminal would.
+	screen struct {
+		rows    [][]cellRun
+		columns int
+	}
+)
+
+// tabWidth of the tab stops.
+const tabWidth = 8
+
+// newScreen lays out the segments into rows, wrapping the rows longer than the given columns, unless zero.
+// The trailing empty row, as of a text ending with a new line, is dropped.
+func newScreen(segments []Segment, columns int) screen {
+	sc := screen{rows: [][]cellRun{nil}}
+	column := 0
+
+	for _, segment := range segments {
+		text := []byte(segment.Text)
+		for len(text) > 0 {
+			size, width := nextCluster(text)
+			cluster := text[:size]
+			text = text[size:]
+
+			switch cluster[0] {
+			case '\n', '\r':
+				if cluster[len(cluster)-1] == '\n' {
+					sc.rows = append(sc.rows, nil)
+				}
+				column = 0
+
+				continue
+			case '\t':
+				cluster, width = []byte{' '}, 1
+				text = append(bytes.Repeat(cluster, tabWidth-column%tabWidth-1), text...)
+			}
+
+			if width == 0 {
+				continue
+			}
+
+			if columns > 0 && column+width > columns {
+				sc.rows = append(sc.rows, nil)
+				column = 0
+			}
+
+			sc.appendCluster(column, width, string(cluster), segment.Style)
+			column += width
+			if column > sc.columns {
+				sc.columns = column
+			}
+		}
+	}
+
+	if last := len(sc.rows) - 1; last > 0 && len(sc.rows[last]) == 0 {
+		sc.rows = sc.rows[:last]
+	}
+
+	if columns > 0 {
+		sc.columns = columns
+	}
+
+	return sc
+}
+
+// appendCluster appends the cluster to the last run of the last row if they share the same style,
+// the wide clusters take their own runs, so the renderers could stretch them over two cells.
+func (sc *screen) appendCluster(column, width int, cluster string, style Style) {
+	row := len(sc.rows) - 1
+	if last := len(sc.rows[row]) - 1; last >= 0 {
+		run := &sc.rows[row][last]
+		if width == 1 && !run.wide && run.style.Equals(style) && run.column+run.width == column {
+			run.text += cluster
+			run.width += width
+
+			return
+		}
+	}
+
+	sc.rows[row] = append(sc.rows[row], cellRun{column: column, width: width, text: cluster, style: style, wide: width > 1})
+}
+
+// resolveColors returns the colors of the style, falling back to the default ones,
+// and swapped for the reversed styles.
+func resolveColors(style Style, foreground, background Color) (Color, Color) {
+	if style.Foreground != nil {
+		foreground = style.Foreground
+	}
+
+	if style.Background != nil {
+		background = style.Background
+	}
+
+	if style.Font.Has(ReverseVideo) {
+		return background, foreground
+	}
+
+	return foreground, background
+}
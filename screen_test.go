@@ -0,0 +1,76 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewScreen(t *testing.T) {
+	t.Parallel()
+
+	bold := Style{Font: Fonts(Bold)}
+
+	testCases := []struct {
+		id       string
+		input    string
+		columns  int
+		expected screen
+	}{
+		{
+			id:    "Should lay out the styled runs by rows, dropping the trailing empty row.",
+			input: "\x1b[1mbold\x1b[0m plain\r\n\nnext\n",
+			expected: screen{
+				rows: [][]cellRun{
+					{{column: 0, width: 4, text: "bold", style: bold}, {column: 4, width: 6, text: " plain"}},
+					nil,
+					{{column: 0, width: 4, text: "next"}},
+				},
+				columns: 10,
+			},
+		},
+		{
+			id:    "Should expand the tabs, and separate the wide characters.",
+			input: "a\t日本",
+			expected: screen{
+				rows: [][]cellRun{
+					{
+						{column: 0, width: 8, text: "a       "},
+						{column: 8, width: 2, text: "日", wide: true},
+						{column: 10, width: 2, text: "本", wide: true},
+					},
+				},
+				columns: 12,
+			},
+		},
+		{
+			id:      "Should wrap the rows longer than the columns.",
+			input:   "abc日",
+			columns: 4,
+			expected: screen{
+				rows: [][]cellRun{
+					{{column: 0, width: 3, text: "abc"}},
+					{{column: 0, width: 2, text: "日", wide: true}},
+				},
+				columns: 4,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, newScreen(Parse(testCase.input), testCase.columns))
+		})
+	}
+}
+
+func TestResolveColors(t *testing.T) {
+	t.Parallel()
+
+	white, black, red := RGB(255, 255, 255), RGB(0, 0, 0), RGB(255, 0, 0)
+
+	foreground, background := resolveColors(Style{Foreground: red}, white, black)
+	assert.Equal(t, []Color{red, black}, []Color{foreground, background})
+
+	foreground, background = resolveColors(Style{Foreground: red, Font: Fonts(ReverseVideo)}, white, black)
+	assert.Equal(t, []Color{black, red}, []Color{foreground, background})
+}
@@ -0,0 +1,309 @@
+//go:build go1.21
+// +build go1.21
+
+package colorize
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+)
+
+type (
+	// HandlerOptions of the Handler, the zero value logs from the info level.
+	HandlerOptions struct {
+		// Level reports the minimum level to log, defaults to slog.LevelInfo.
+		Level slog.Leveler
+		// AddSource logs the file and the line of the log call.
+		AddSource bool
+		// ReplaceAttr rewrites or drops the attributes, as for slog.HandlerOptions.
+		ReplaceAttr func(groups []string, attr slog.Attr) slog.Attr
+		// TimeFormat of the timestamps, defaults to "15:04:05.000".
+		TimeFormat string
+		// Styles of the record parts, defaults to DefaultHandlerStyles.
+		Styles *HandlerStyles
+	}
+
+	// HandlerStyles of the record parts.
+	HandlerStyles struct {
+		// Level badges.
+		DebugLevel Style
+		InfoLevel  Style
+		WarnLevel  Style
+		ErrorLevel Style
+		Timestamp  Style
+		Message    Style
+		Key        Style
+		Source     Style
+		// Values by their types.
+		String   Style
+		Number   Style
+		Bool     Style
+		Duration Style
+		Time     Style
+		Error    Style
+	}
+
+	// Handler is a slog.Handler writing the records as styled text, e.g.:
+	// 15:04:05.000 INFO  "request served" path=/ status=200 duration=1.5ms
+	// The message, the keys and the string values are quoted as by slog.TextHandler,
+	// when they are empty or have spaces, quotes, "=" or non printable characters.
+	// The output is not colored if the Colorable output is not a terminal, unless the color is enabled explicitly.
+	Handler struct {
+		colorable *Colorable
+		options   HandlerOptions
+		output    io.Writer
+		plain     bool
+		// mux is shared by the handlers derived by WithAttrs() and WithGroup().
+		mux *sync.Mutex
+		// attributes rendered by WithAttrs().
+		attributes []byte
+		// groups opened by WithGroup(), prefix is their keys prefix, as in "request.".
+		groups []string
+		prefix string
+	}
+)
+
+const defaultTimeFormat = "15:04:05.000"
+
+// DefaultHandlerStyles of the Handler.
+var DefaultHandlerStyles = HandlerStyles{
+	DebugLevel: Style{Foreground: RGB(128, 128, 128), Font: Fonts(Bold)},
+	InfoLevel:  Style{Foreground: RGB(0, 255, 0), Font: Fonts(Bold)},
+	WarnLevel:  Style{Foreground: RGB(255, 255, 0), Font: Fonts(Bold)},
+	ErrorLevel: Style{Foreground: RGB(255, 0, 0), Font: Fonts(Bold)},
+	Timestamp:  Style{Font: Fonts(Faint)},
+	Key:        Style{Foreground: RGB(0, 255, 255)},
+	Source:     Style{Font: Fonts(Faint, Underline)},
+	String:     Style{Foreground: RGB(255, 165, 0)},
+	Number:     Style{Foreground: RGB(255, 0, 255)},
+	Bool:       Style{Foreground: RGB(255, 255, 0)},
+	Duration:   Style{Foreground: RGB(128, 0, 128)},
+	Time:       Style{Font: Fonts(Faint)},
+	Error:      Style{Foreground: RGB(255, 0, 0)},
+}
+
+// NewHandler allocates and returns a new Handler, writing into the Colorable output.
+// e.g.: slog.SetDefault(slog.New(NewHandler(NewColorable(os.Stderr), nil)))
+func NewHandler(c *Colorable, options *HandlerOptions) *Handler {
+	h := &Handler{
+		colorable: c,
+		mux:       &sync.Mutex{},
+	}
+	if options != nil {
+		h.options = *options
+	}
+	if h.options.Level == nil {
+		h.options.Level = slog.LevelInfo
+	}
+	if h.options.TimeFormat == "" {
+		h.options.TimeFormat = defaultTimeFormat
+	}
+	if h.options.Styles == nil {
+		h.options.Styles = &DefaultHandlerStyles
+	}
+
+	c.mux.Lock()
+	h.output = c.output
+	h.plain = c.isColorActive == nil && !isTerminal(c.output)
+	c.mux.Unlock()
+
+	return h
+}
+
+// Enabled reports whether the level is logged.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.options.Level.Level()
+}
+
+// Handle writes the record as a single line.
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	buffer := make([]byte, 0, 256)
+	styles := h.options.Styles
+
+	if !record.Time.IsZero() {
+		buffer = h.appendBuiltIn(buffer, slog.Time(slog.TimeKey, record.Time), styles.Timestamp)
+	}
+	buffer = h.appendBuiltIn(buffer, slog.Any(slog.LevelKey, record.Level), h.levelStyle(record.Level))
+	buffer = h.appendBuiltIn(buffer, slog.String(slog.MessageKey, record.Message), styles.Message)
+
+	buffer = append(buffer, h.attributes...)
+	record.Attrs(func(attr slog.Attr) bool {
+		buffer = h.appendAttr(buffer, attr, h.prefix, h.groups)
+
+		return true
+	})
+
+	if h.options.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		source := &slog.Source{Function: frame.Function, File: frame.File, Line: frame.Line}
+		buffer = h.appendAttr(buffer, slog.Any(slog.SourceKey, source), "", nil)
+	}
+
+	if len(buffer) > 0 && buffer[len(buffer)-1] == ' ' {
+		buffer = buffer[:len(buffer)-1]
+	}
+	buffer = append(buffer, '\n')
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	_, err := h.output.Write(buffer)
+
+	return err
+}
+
+// WithAttrs returns a handler logging the attributes with each record.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := *h
+	derived.attributes = append([]byte(nil), h.attributes...)
+	for _, attr := range attrs {
+		derived.attributes = h.appendAttr(derived.attributes, attr, h.prefix, h.groups)
+	}
+
+	return &derived
+}
+
+// WithGroup returns a handler qualifying the following attributes by the group name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	derived := *h
+	derived.groups = append(append([]string(nil), h.groups...), name)
+	derived.prefix = h.prefix + name + "."
+
+	return &derived
+}
+
+// appendBuiltIn appends the value of a built-in attribute, unless dropped by ReplaceAttr().
+func (h *Handler) appendBuiltIn(dst []byte, attr slog.Attr, style Style) []byte {
+	replaced := h.replace(nil, attr)
+	if replaced.Equal(slog.Attr{}) {
+		return dst
+	}
+
+	var value string
+	switch {
+	case replaced.Key == slog.TimeKey && replaced.Value.Kind() == slog.KindTime:
+		value = replaced.Value.Time().Format(h.options.TimeFormat)
+	case replaced.Key == slog.LevelKey && replaced.Value.Equal(attr.Value):
+		value = fmt.Sprintf("%-5s", attr.Value.Any())
+	default:
+		value = quoteValue(replaced.Value.String())
+	}
+
+	return append(h.appendStyled(dst, style, value), ' ')
+}
+
+// appendAttr appends the attribute as key=value, flattening the groups as group.key=value.
+func (h *Handler) appendAttr(dst []byte, attr slog.Attr, prefix string, groups []string) []byte {
+	attr.Value = attr.Value.Resolve()
+	if attr.Value.Kind() != slog.KindGroup {
+		attr = h.replace(groups, attr)
+		attr.Value = attr.Value.Resolve()
+	}
+
+	if attr.Equal(slog.Attr{}) {
+		return dst
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+			groups = append(groups[:len(groups):len(groups)], attr.Key)
+		}
+
+		for _, groupAttr := range attr.Value.Group() {
+			dst = h.appendAttr(dst, groupAttr, prefix, groups)
+		}
+
+		return dst
+	}
+
+	dst = h.appendStyled(dst, h.options.Styles.Key, quoteValue(prefix+attr.Key))
+	dst = append(dst, '=')
+	dst = h.appendValue(dst, attr.Value)
+
+	return append(dst, ' ')
+}
+
+// appendValue appends the value, styled by its type.
+func (h *Handler) appendValue(dst []byte, value slog.Value) []byte {
+	styles := h.options.Styles
+
+	switch value.Kind() {
+	case slog.KindString:
+		return h.appendStyled(dst, styles.String, quoteValue(value.String()))
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64:
+		return h.appendStyled(dst, styles.Number, value.String())
+	case slog.KindBool:
+		return h.appendStyled(dst, styles.Bool, value.String())
+	case slog.KindDuration:
+		return h.appendStyled(dst, styles.Duration, value.String())
+	case slog.KindTime:
+		return h.appendStyled(dst, styles.Time, value.Time().Format(time.RFC3339))
+	}
+
+	if source, ok := value.Any().(*slog.Source); ok {
+		return h.appendStyled(dst, styles.Source, filepath.Base(source.File)+":"+strconv.Itoa(source.Line))
+	}
+
+	if err, ok := value.Any().(error); ok {
+		return h.appendStyled(dst, styles.Error, quoteValue(err.Error()))
+	}
+
+	return append(dst, quoteValue(value.String())...)
+}
+
+func (h *Handler) appendStyled(dst []byte, style Style, s string) []byte {
+	if h.plain || style.IsZero() {
+		return append(dst, s...)
+	}
+
+	return h.colorable.Append(dst, style, s)
+}
+
+func (h *Handler) replace(groups []string, attr slog.Attr) slog.Attr {
+	if h.options.ReplaceAttr == nil {
+		return attr
+	}
+
+	return h.options.ReplaceAttr(groups, attr)
+}
+
+func (h *Handler) levelStyle(level slog.Level) Style {
+	switch {
+	case level >= slog.LevelError:
+		return h.options.Styles.ErrorLevel
+	case level >= slog.LevelWarn:
+		return h.options.Styles.WarnLevel
+	case level >= slog.LevelInfo:
+		return h.options.Styles.InfoLevel
+	}
+
+	return h.options.Styles.DebugLevel
+}
+
+// quoteValue quotes the empty values, and the ones with spaces, quotes, "=" or non printable characters.
+func quoteValue(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	for _, char := range s {
+		if unicode.IsSpace(char) || char == '"' || char == '=' || !unicode.IsPrint(char) {
+			return strconv.Quote(s)
+		}
+	}
+
+	return s
+}
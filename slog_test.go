@@ -0,0 +1,189 @@
+//go:build go1.21
+// +build go1.21
+
+package colorize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	withoutTime := func(groups []string, attr slog.Attr) slog.Attr {
+		if len(groups) == 0 && attr.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+
+		return attr
+	}
+
+	testCases := []struct {
+		id       string
+		options  HandlerOptions
+		log      func(logger *slog.Logger)
+		expected string
+	}{
+		{
+			id:      "Should render the typed values.",
+			options: HandlerOptions{ReplaceAttr: withoutTime},
+			log: func(logger *slog.Logger) {
+				logger.Info(
+					"served",
+					"path", "/index",
+					"query", "a b",
+					"status", 200,
+					"cached", false,
+					"took", 1500*time.Microsecond,
+					"err", errors.New("timeout"),
+				)
+			},
+			expected: "INFO  served path=/index query=\"a b\" status=200 cached=false took=1.5ms err=timeout\n",
+		},
+		{
+			id:      "Should quote the message and the keys as slog.TextHandler.",
+			options: HandlerOptions{ReplaceAttr: withoutTime},
+			log: func(logger *slog.Logger) {
+				logger.Info("request served", "user id", 7, "a=b", "c", "say\"", "d", "tab\tkey", "e", "", "f")
+				logger.Info("")
+			},
+			expected: "INFO  \"request served\" \"user id\"=7 \"a=b\"=c \"say\\\"\"=d \"tab\\tkey\"=e \"\"=f\n" +
+				"INFO  \"\"\n",
+		},
+		{
+			id:      "Should qualify the attributes by the groups.",
+			options: HandlerOptions{ReplaceAttr: withoutTime},
+			log: func(logger *slog.Logger) {
+				logger.With("app", "api").WithGroup("request").With("id", 7).Warn(
+					"slow",
+					slog.Group("user", "name", "alice"),
+					slog.Group("empty"),
+				)
+			},
+			expected: "WARN  slow app=api request.id=7 request.user.name=alice\n",
+		},
+		{
+			id: "Should replace and drop the attributes.",
+			options: HandlerOptions{
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					switch attr.Key {
+					case slog.TimeKey, "secret":
+						return slog.Attr{}
+					case slog.LevelKey:
+						return slog.String(slog.LevelKey, "E")
+					case "name":
+						return slog.String("name", strings.Join(groups, "/")+":"+attr.Value.String())
+					}
+
+					return attr
+				},
+			},
+			log: func(logger *slog.Logger) {
+				logger.Error("failed", "secret", "x", slog.Group("user", "name", "bob"))
+			},
+			expected: "E failed user.name=user:bob\n",
+		},
+		{
+			id:      "Should skip the records below the level.",
+			options: HandlerOptions{ReplaceAttr: withoutTime, Level: slog.LevelWarn},
+			log: func(logger *slog.Logger) {
+				logger.Info("skipped")
+				logger.Debug("skipped")
+				logger.Log(context.Background(), slog.LevelError+2, "logged")
+			},
+			expected: "ERROR+2 logged\n",
+		},
+		{
+			id:      "Should format the timestamps.",
+			options: HandlerOptions{TimeFormat: "2006"},
+			log: func(logger *slog.Logger) {
+				record := slog.NewRecord(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), slog.LevelDebug, "dated", 0)
+				logger.Handler().Handle(context.Background(), record)
+			},
+			expected: "2024 DEBUG dated\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			options := testCase.options
+			testCase.log(slog.New(NewHandler(NewColorable(output), &options)))
+
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestHandlerColors(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	options := &HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+		Styles: &HandlerStyles{
+			InfoLevel: Style{Font: Fonts(Bold)},
+			Key:       Style{Font: Fonts(Faint)},
+			Number:    Style{Font: Fonts(Italic)},
+		},
+	}
+	logger := slog.New(NewHandler(NewColorable(output).EnableColor(), options))
+
+	logger.Info("done", "count", 3, "name", "x")
+
+	assert.Equal(t, "\x1b[1mINFO \x1b[0m done \x1b[2mcount\x1b[0m=\x1b[3m3\x1b[0m \x1b[2mname\x1b[0m=x\n", output.String())
+}
+
+func TestHandlerSource(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	logger := slog.New(NewHandler(NewColorable(output), &HandlerOptions{AddSource: true}))
+
+	logger.Info("sourced")
+
+	assert.Regexp(t, `^\d\d:\d\d:\d\d\.\d{3} INFO  sourced source=slog_test\.go:\d+\n$`, output.String())
+}
+
+func TestHandlerReplaceSource(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	calls := 0
+	options := &HandlerOptions{
+		AddSource: true,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			switch attr.Key {
+			case slog.TimeKey:
+				return slog.Attr{}
+			case slog.SourceKey:
+				calls++
+				source, ok := attr.Value.Any().(*slog.Source)
+				assert.True(t, ok)
+
+				return slog.String(slog.SourceKey, "<<"+source.Function+">>")
+			}
+
+			return attr
+		},
+	}
+	logger := slog.New(NewHandler(NewColorable(output), options))
+
+	logger.Info("sourced")
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "INFO  sourced source=<<github.com/ahmedkamals/colorize.TestHandlerReplaceSource>>\n", output.String())
+}
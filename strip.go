@@ -0,0 +1,70 @@
+package colorize
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+type (
+	// StripWriter removes the escape sequences of the written data,
+	// before passing it to the underlying writer.
+	StripWriter struct {
+		mux     sync.Mutex // protects all the below fields
+		output  io.Writer
+		scanner sequenceScanner
+		buffer  []byte
+	}
+)
+
+// Strip removes the escape sequences (CSI, OSC, SGR...) of s, e.g. to log styled output into files.
+func Strip(s string) string {
+	if strings.IndexByte(s, escape) < 0 {
+		return s
+	}
+
+	stripped := make([]byte, 0, len(s))
+	scanner := sequenceScanner{}
+	scanner.scan(
+		[]byte(s),
+		func(text []byte) {
+			stripped = append(stripped, text...)
+		},
+		func([]byte) {},
+	)
+
+	return string(stripped)
+}
+
+// NewStripWriter allocates and returns a new StripWriter, writing to the given output.
+// Sequences split across the Write() calls are removed as well.
+// e.g.: log.SetOutput(NewStripWriter(file))
+func NewStripWriter(output io.Writer) *StripWriter {
+	return &StripWriter{
+		output: output,
+	}
+}
+
+// Write writes p into the underlying writer without the escape sequences,
+// and returns len(p) on success, as the stripped bytes are considered written.
+func (sw *StripWriter) Write(p []byte) (n int, err error) {
+	sw.mux.Lock()
+	defer sw.mux.Unlock()
+
+	sw.buffer = sw.buffer[:0]
+	sw.scanner.scan(
+		p,
+		func(text []byte) {
+			sw.buffer = append(sw.buffer, text...)
+		},
+		func([]byte) {},
+	)
+
+	if len(sw.buffer) > 0 {
+		if _, err = sw.output.Write(sw.buffer); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,124 @@
+package colorize
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected string
+	}{
+		{
+			id:       "Should return the plain text as is.",
+			input:    "plain text",
+			expected: "plain text",
+		},
+		{
+			id:       "Should strip the SGR sequences.",
+			input:    "\x1b[38;2;255;0;0;1mred\x1b[0m and \x1b[4:3;58;5;1mcurly\x1b[m",
+			expected: "red and curly",
+		},
+		{
+			id:       "Should strip the CSI sequences.",
+			input:    "\x1b[2J\x1b[1;1Hcleared\x1b[K\x1b[?25l",
+			expected: "cleared",
+		},
+		{
+			id:       "Should strip the OSC sequences terminated by ST or BEL.",
+			input:    "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\ \x1b]0;title\atitled",
+			expected: "link titled",
+		},
+		{
+			id:       "Should strip the DCS and the two characters sequences.",
+			input:    "\x1bPq#0;2;0;0;0\x1b\\\x1b7saved\x1b8\x1b(Bcharset",
+			expected: "savedcharset",
+		},
+		{
+			id:       "Should keep the multi-byte characters.",
+			input:    "\x1b[1mschön 日本\x1b[0m",
+			expected: "schön 日本",
+		},
+		{
+			id:       "Should scan an unterminated sequence as text once longer than the limit.",
+			input:    "before \x1b]0;title\n" + strings.Repeat("INFO served\n", 1600),
+			expected: "before 0;title\n" + strings.Repeat("INFO served\n", 1600),
+		},
+		{
+			id:       "Should strip the sequences following an unterminated one.",
+			input:    "\x1b_" + strings.Repeat("x", maxSequenceSize) + "\x1b[1mbold\x1b[0m",
+			expected: strings.Repeat("x", maxSequenceSize) + "bold",
+		},
+		{
+			id:       "Should drop an unterminated sequence.",
+			input:    "text\x1b]8;;https://example.com",
+			expected: "text",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Strip(testCase.input))
+		})
+	}
+}
+
+func TestStripWriter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    []string
+		expected string
+	}{
+		{
+			id:       "Should strip the sequences of each write.",
+			input:    []string{"\x1b[1mbold\x1b[0m", " \x1b[3mitalic\x1b[0m"},
+			expected: "bold italic",
+		},
+		{
+			id:       "Should write an unterminated sequence split across the writes as text.",
+			input:    []string{"log \x1b]", strings.Repeat("line\n", 500), strings.Repeat("line\n", 500)},
+			expected: "log " + strings.Repeat("line\n", 1000),
+		},
+		{
+			id:       "Should strip the sequences split across the writes.",
+			input:    []string{"\x1b", "[38;2;", "255;0;0mred\x1b[", "0m \x1b]8;;https://", "example.com\x1b", "\\link\x1b]8;;\x1b\\"},
+			expected: "red link",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			writer := NewStripWriter(output)
+
+			for _, input := range testCase.input {
+				n, err := fmt.Fprint(writer, input)
+
+				assert.NoError(t, err)
+				assert.Equal(t, len(input), n)
+			}
+
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestStripColorableOutput(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	colorized := NewColorable(NewStripWriter(output)).EnableColor().SetLineMode(FillLines)
+
+	colorized.Println(Style{Foreground: RGB(255, 0, 0), Link: Link{URL: "https://example.com"}}, "styled\nlines")
+
+	assert.Equal(t, "styled\nlines\n", output.String())
+}
@@ -7,75 +7,305 @@ import (
 )
 
 type (
-	// Style to be applied to the text.
+	// Style to be applied to the text, comparable with == and usable as a map key.
+	// The font effects are a set, e.g.: Style{Font: Fonts(Bold, Italic)}, or Fonts(effects...) for a []FontEffect slice.
 	Style struct {
 		fmt.Formatter
 		fmt.Stringer
-		Foreground Color
-		Background Color
-		Font       []FontEffect
+		Foreground     Color
+		Background     Color
+		Font           FontEffects
+		UnderlineStyle UnderlineStyle
+		UnderlineColor Color
+		Link           Link
 	}
+
+	// UnderlineStyle value, as in the SGR 4:x sub-parameter.
+	UnderlineStyle byte
+
+	// CompiledStyle is a Style frozen into prebuilt open and close sequences,
+	// to be created once and reused by the hot output paths.
+	CompiledStyle struct {
+		style Style
+		open  []byte
+		close []byte
+		// openString and closeString hold the sequences too, so Open() and Close() do not allocate.
+		openString  string
+		closeString string
+	}
+)
+
+// Underline styles.
+// Terminals without support fall back to a plain underline.
+const (
+	NoUnderline UnderlineStyle = iota
+	SingleUnderline
+	DoubleUnderline
+	CurlyUnderline
+	DottedUnderline
+	DashedUnderline
 )
 
 const (
-	// resetFormat for single/multiple value(s), e.g. \x1b[0m
-	resetFormat = "\u001b[%dm"
-	// colorFormat for color values, e.g. \x1b[38;2;0;0;0;48;2;255;0;255m
-	colorFormat = "\x1b[%sm"
+	// sgrPrefix of the color/font sequences, e.g. \x1b[38;2;0;0;0;48;2;255;0;255m
+	sgrPrefix = "\x1b["
+	// sgrSuffix of the color/font sequences.
+	sgrSuffix = 'm'
+	// resetSequence for all the applied attributes.
+	resetSequence = "\x1b[0m"
+	// maxSequenceLength of a style, enough for three colors and a dozen of font effects.
+	maxSequenceLength = 96
 
 	foreground = colorMode(38)
 	background = colorMode(48)
+	underline  = colorMode(58)
 )
 
 // Equals compares style with a given style,
 // and returns true if they are the same.
 func (s Style) Equals(style Style) bool {
-	if len(s.Font) != len(style.Font) ||
-		(s.Foreground == nil && style.Foreground != nil) ||
-		(s.Foreground != nil && !s.Foreground.Equals(style.Foreground)) ||
-		(s.Background == nil && style.Background != nil) ||
-		(s.Background != nil && !s.Background.Equals(style.Background)) {
-		return false
+	return s.Font == style.Font &&
+		s.UnderlineStyle == style.UnderlineStyle &&
+		s.Link == style.Link &&
+		colorEquals(s.Foreground, style.Foreground) &&
+		colorEquals(s.Background, style.Background) &&
+		colorEquals(s.UnderlineColor, style.UnderlineColor)
+}
+
+// Inherit returns a copy of the style, with its unset fields taken from the outer style,
+// and the font effects of both.
+func (s Style) Inherit(outer Style) Style {
+	if s.Foreground == nil {
+		s.Foreground = outer.Foreground
 	}
 
-	for _, font := range s.Font {
-		if !fontExists(font, style.Font) {
-			return false
-		}
+	if s.Background == nil {
+		s.Background = outer.Background
+	}
+
+	if s.UnderlineStyle == NoUnderline {
+		s.UnderlineStyle = outer.UnderlineStyle
 	}
 
-	return true
+	if s.UnderlineColor == nil {
+		s.UnderlineColor = outer.UnderlineColor
+	}
+
+	if s.Link.IsZero() {
+		s.Link = outer.Link
+	}
+
+	s.Font |= outer.Font
+
+	return s
+}
+
+// IsZero returns true if the style has no attribute set.
+func (s Style) IsZero() bool {
+	return s.Equals(Style{})
 }
 
 // Format to an 24-bit ANSI escape sequence
-// an example output might be: "[38;2;255;0;0m" -> Red color
+// an example output might be: "[38;2;255;0;0m" -> Red color
 func (s Style) Format(fs fmt.State, verb rune) {
-	format := make([]string, 0)
+	switch verb {
+	case 's', 'v':
+		fs.Write(s.AppendSGR(make([]byte, 0, maxSequenceLength)))
+	}
+}
+
+func (s Style) String() string {
+	return string(s.AppendSGR(make([]byte, 0, maxSequenceLength)))
+}
+
+// AppendSGR appends the escape sequence of the style to dst, and returns the extended buffer.
+// e.g.: buffer = style.AppendSGR(buffer[:0])
+func (s Style) AppendSGR(dst []byte) []byte {
+	dst = append(dst, sgrPrefix...)
+	dst = s.appendParameters(dst, len(dst))
+
+	return append(dst, sgrSuffix)
+}
+
+// Compile freezes the style into prebuilt open and close sequences,
+// including the hyperlink if any.
+func (s Style) Compile() CompiledStyle {
+	return s.compile(true)
+}
+
+// Style returns the compiled style.
+func (cs CompiledStyle) Style() Style {
+	return cs.style
+}
+
+// Open returns the sequence that applies the style.
+func (cs CompiledStyle) Open() string {
+	return cs.openString
+}
+
+// Close returns the sequence that resets the style.
+func (cs CompiledStyle) Close() string {
+	return cs.closeString
+}
+
+// Append appends s wrapped with the style to dst, and returns the extended buffer.
+func (cs CompiledStyle) Append(dst []byte, s string) []byte {
+	dst = append(dst, cs.open...)
+	dst = append(dst, s...)
+
+	return append(dst, cs.close...)
+}
+
+// compile freezes the style, the link falls back to "text (url)" without hyperlinks support.
+func (s Style) compile(hyperlinks bool) CompiledStyle {
+	compiled := CompiledStyle{
+		style: s,
+		open:  s.appendOpen(nil, hyperlinks),
+		close: s.appendClose(nil, hyperlinks),
+	}
+	compiled.openString, compiled.closeString = string(compiled.open), string(compiled.close)
+
+	return compiled
+}
 
+// appendOpen appends the sequences that apply the style, and start the hyperlink if supported.
+func (s Style) appendOpen(dst []byte, hyperlinks bool) []byte {
+	if hyperlinks && !s.Link.IsZero() {
+		dst = s.Link.appendOpen(dst)
+	}
+
+	return s.AppendSGR(dst)
+}
+
+// appendClose appends the sequences that reset the style, and end the hyperlink if supported.
+func (s Style) appendClose(dst []byte, hyperlinks bool) []byte {
+	dst = append(dst, resetSequence...)
+
+	switch {
+	case s.Link.IsZero():
+		return dst
+	case hyperlinks:
+		return append(dst, hyperlinkClose...)
+	default:
+		return s.Link.appendFallback(dst)
+	}
+}
+
+// appendParameters appends the SGR parameters of the style, separated by ";",
+// the parameters list starts at the given offset of the buffer.
+func (s Style) appendParameters(parameters []byte, offset int) []byte {
 	if s.Foreground != nil {
-		format = append(format, s.Foreground.generate(foreground))
+		parameters = s.Foreground.appendParameters(appendSeparator(parameters, offset), foreground)
 	}
 
 	if s.Background != nil {
-		format = append(format, s.Background.generate(background))
+		parameters = s.Background.appendParameters(appendSeparator(parameters, offset), background)
 	}
 
-	if s.Font != nil && len(s.Font) > 0 {
-		for _, fontEffect := range s.Font {
-			format = append(format, strconv.FormatInt(int64(fontEffect), 10))
-		}
+	parameters = s.Font.appendParameters(parameters, offset)
+
+	if s.UnderlineStyle != NoUnderline {
+		parameters = append(appendSeparator(parameters, offset), '4', ':')
+		parameters = strconv.AppendInt(parameters, int64(s.UnderlineStyle), 10)
 	}
 
-	switch verb {
-	case 's', 'v':
-		fmt.Fprintf(fs, colorFormat, strings.Join(format, ";"))
+	if s.UnderlineColor != nil {
+		parameters = s.UnderlineColor.appendParameters(appendSeparator(parameters, offset), underline)
 	}
+
+	return parameters
 }
 
-func (s Style) String() string {
-	return fmt.Sprintf("%s", s)
+// appendSeparator appends ";" to a non empty parameters list, starting at the given offset.
+func appendSeparator(parameters []byte, offset int) []byte {
+	if len(parameters) == offset {
+		return parameters
+	}
+
+	return append(parameters, ';')
 }
 
-func (s Style) resetFormat() string {
-	return fmt.Sprintf(resetFormat, Normal)
+func colorEquals(color, counterpart Color) bool {
+	if color == nil {
+		return counterpart == nil
+	}
+
+	return color.Equals(counterpart)
+}
+
+// appendReapplied appends s to dst, followed by the open sequence after each reset sequence of s,
+// so a foreign reset does not clear the applied style. The attributes combined with a reset,
+// as in \x1b[0;31m, are applied after the open sequence, so they still take precedence.
+func appendReapplied(dst []byte, s string, open []byte) []byte {
+	for len(s) > 0 {
+		index := strings.Index(s, sgrPrefix)
+		if index < 0 {
+			return append(dst, s...)
+		}
+
+		length, parameters := splitResetSequence(s[index:])
+		switch {
+		case length == 0:
+			dst = append(dst, s[:index+len(sgrPrefix)]...)
+			s = s[index+len(sgrPrefix):]
+
+			continue
+		case parameters == "":
+			dst = append(dst, s[:index+length]...)
+			dst = append(dst, open...)
+		default:
+			dst = append(dst, s[:index]...)
+			dst = append(dst, resetSequence...)
+			dst = append(dst, open...)
+			dst = append(dst, sgrPrefix...)
+			dst = append(dst, parameters...)
+			dst = append(dst, sgrSuffix)
+		}
+
+		s = s[index+length:]
+	}
+
+	return dst
+}
+
+// splitResetSequence returns the length of the SGR sequence that starts s, if it starts with a reset,
+// as in \x1b[m, \x1b[0m or \x1b[0;31m, otherwise zero, along with the parameters following the reset.
+func splitResetSequence(s string) (length int, parameters string) {
+	for index := len(sgrPrefix); index < len(s); index++ {
+		switch character := s[index]; {
+		case character == sgrSuffix:
+			return splitResetParameters(s[len(sgrPrefix):index], index+1)
+		case (character < '0' || character > '9') && character != ';' && character != ':':
+			return 0, ""
+		}
+	}
+
+	return 0, ""
+}
+
+// splitResetParameters drops the leading reset parameters, returning zero length if there is none.
+func splitResetParameters(parameters string, length int) (int, string) {
+	reset := false
+	for {
+		parameter, rest := parameters, ""
+		separator := strings.IndexByte(parameters, ';')
+		if separator >= 0 {
+			parameter, rest = parameters[:separator], parameters[separator+1:]
+		}
+
+		if strings.Trim(parameter, "0") != "" {
+			break
+		}
+
+		reset, parameters = true, rest
+		if separator < 0 {
+			break
+		}
+	}
+
+	if !reset {
+		return 0, ""
+	}
+
+	return length, parameters
 }
@@ -43,10 +43,10 @@ func TestEquals(t *testing.T) {
 		{
 			id: "Should return true if the styles Fonts' length and values are the same.",
 			input: Style{
-				Font: []FontEffect{Bold, Italic},
+				Font: Fonts(Bold, Italic),
 			},
 			compared: Style{
-				Font: []FontEffect{Italic, Bold},
+				Font: Fonts(Italic, Bold),
 			},
 			expected: true,
 		},
@@ -67,12 +67,12 @@ func TestEquals(t *testing.T) {
 			input: Style{
 				Foreground: RGB(0, 0, 0),
 				Background: RGB(255, 255, 255),
-				Font:       []FontEffect{Bold},
+				Font:       Fonts(Bold),
 			},
 			compared: Style{
 				Foreground: RGB(0, 0, 0),
 				Background: RGB(255, 255, 255),
-				Font:       []FontEffect{Bold},
+				Font:       Fonts(Bold),
 			},
 			expected: true,
 		},
@@ -95,20 +95,20 @@ func TestEquals(t *testing.T) {
 		{
 			id: "Should return false if the styles Fonts' length are not the same.",
 			input: Style{
-				Font: []FontEffect{},
+				Font: Fonts(),
 			},
 			compared: Style{
-				Font: []FontEffect{Bold},
+				Font: Fonts(Bold),
 			},
 			expected: false,
 		},
 		{
 			id: "Should return false if the styles Fonts' length and values are not the same.",
 			input: Style{
-				Font: []FontEffect{Bold, Italic},
+				Font: Fonts(Bold, Italic),
 			},
 			compared: Style{
-				Font: []FontEffect{Underline, Bold},
+				Font: Fonts(Underline, Bold),
 			},
 			expected: false,
 		},
@@ -124,12 +124,42 @@ func TestEquals(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			id: "Should return true if the styles underline styles and colors are the same.",
+			input: Style{
+				UnderlineStyle: CurlyUnderline,
+				UnderlineColor: RGB(255, 0, 0),
+			},
+			compared: Style{
+				UnderlineStyle: CurlyUnderline,
+				UnderlineColor: RGB(255, 0, 0),
+			},
+			expected: true,
+		},
+		{
+			id: "Should return false if the styles underline styles are not the same.",
+			input: Style{
+				UnderlineStyle: CurlyUnderline,
+			},
+			compared: Style{
+				UnderlineStyle: DottedUnderline,
+			},
+			expected: false,
+		},
+		{
+			id: "Should return false if the styles underline colors are not the same.",
+			input: Style{
+				UnderlineColor: RGB(255, 0, 0),
+			},
+			compared: Style{},
+			expected: false,
+		},
 		{
 			id: "Should return false if the styles Foregrounds', Backgrounds' or Fonts' are not the same.",
 			input: Style{
 				Foreground: RGB(0, 0, 0),
 				Background: RGB(255, 255, 255),
-				Font:       []FontEffect{Bold},
+				Font:       Fonts(Bold),
 			},
 			compared: Style{
 				Foreground: RGB(0, 0, 0),
@@ -145,3 +175,124 @@ func TestEquals(t *testing.T) {
 		})
 	}
 }
+
+func TestStyleComparable(t *testing.T) {
+	t.Parallel()
+
+	styles := map[Style]string{
+		{Foreground: RGB(255, 0, 0), Font: Fonts(Bold, Bold)}: "error",
+		{Foreground: RGB(0, 255, 0), Font: Fonts(Italic)}:     "success",
+	}
+
+	assert.Equal(t, "error", styles[Style{Foreground: RGB(255, 0, 0), Font: Fonts(Bold)}])
+	assert.Equal(t, "success", styles[Style{Foreground: RGB(0, 255, 0), Font: Fonts(Italic)}])
+	assert.True(t, Style{Font: Fonts(Bold, Italic)} == Style{Font: Fonts(Italic, Bold)})
+	assert.False(t, Style{Font: Fonts(Bold, Bold)} == Style{Font: Fonts(Bold, Italic)})
+	assert.Equal(t, Style{Font: Fonts(Bold)}.String(), Style{Font: Fonts(Bold, Bold)}.String())
+	assert.True(t, Style{Font: Fonts(FontEffect(42))}.IsZero())
+	assert.Equal(t, "\x1b[m", Style{Font: Fonts(FontEffect(42))}.String())
+}
+
+func TestAppendSGR(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    Style
+		buffer   []byte
+		expected string
+	}{
+		{
+			id:       "Should append an empty sequence for an empty style.",
+			input:    Style{},
+			expected: "\x1b[m",
+		},
+		{
+			id: "Should append the sequence to an existing buffer.",
+			input: Style{
+				Foreground: RGB(255, 0, 0),
+				Font:       Fonts(Bold),
+			},
+			buffer:   []byte("prefix "),
+			expected: "prefix \x1b[38;2;255;0;0;1m",
+		},
+		{
+			id: "Should append fonts without a leading separator.",
+			input: Style{
+				Font:           Fonts(Italic, Underline),
+				UnderlineStyle: DottedUnderline,
+			},
+			expected: "\x1b[3;4;4:4m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, string(testCase.input.AppendSGR(testCase.buffer)))
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	style := Style{
+		Foreground: RGB(0, 255, 0),
+		Background: RGB(0, 0, 0),
+	}
+	compiled := style.Compile()
+
+	assert.Equal(t, style, compiled.Style())
+	assert.Equal(t, "\x1b[38;2;0;255;0;48;2;0;0;0m", compiled.Open())
+	assert.Equal(t, "\x1b[0m", compiled.Close())
+	assert.Equal(
+		t,
+		"> \x1b[38;2;0;255;0;48;2;0;0;0mcompiled\x1b[0m",
+		string(compiled.Append([]byte("> "), "compiled")),
+	)
+}
+
+func TestCompiledStyleAllocations(t *testing.T) {
+	compiled := Style{Foreground: RGB(0, 255, 0), Font: Fonts(Bold)}.Compile()
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		compiled.Open()
+		compiled.Close()
+	}))
+}
+
+func TestInherit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    Style
+		outer    Style
+		expected Style
+	}{
+		{
+			id:       "Should inherit the unset colors.",
+			input:    Style{Background: RGB(0, 0, 0)},
+			outer:    Style{Foreground: RGB(255, 0, 0), Background: RGB(255, 255, 255)},
+			expected: Style{Foreground: RGB(255, 0, 0), Background: RGB(0, 0, 0)},
+		},
+		{
+			id:       "Should combine the font effects.",
+			input:    Style{Font: Fonts(Italic)},
+			outer:    Style{Font: Fonts(Bold)},
+			expected: Style{Font: Fonts(Bold, Italic)},
+		},
+		{
+			id:       "Should inherit the underline and the link.",
+			input:    Style{UnderlineStyle: CurlyUnderline},
+			outer:    Style{UnderlineStyle: DottedUnderline, UnderlineColor: RGB(0, 255, 0), Link: Link{URL: "https://example.com"}},
+			expected: Style{UnderlineStyle: CurlyUnderline, UnderlineColor: RGB(0, 255, 0), Link: Link{URL: "https://example.com"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.input.Inherit(testCase.outer))
+		})
+	}
+}
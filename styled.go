@@ -0,0 +1,92 @@
+package colorize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// StyledValue formats its value by the fmt verbs, then wraps it with the style,
+	// padding it to the width by its visible width, see Styled().
+	StyledValue struct {
+		value     interface{}
+		style     Style
+		colorable *Colorable
+	}
+)
+
+// formatFlags supported by fmt.
+const formatFlags = "+-# 0"
+
+// Styled returns the value wrapped with the style when formatted, following the global color settings.
+// e.g.: fmt.Printf("%-10v %5.2f", Styled(name, bold), Styled(ratio, red))
+func Styled(value interface{}, style Style) StyledValue {
+	return StyledValue{
+		value: value,
+		style: style,
+	}
+}
+
+// Styled returns the value wrapped with the style when formatted, following the Colorable settings.
+func (c *Colorable) Styled(value interface{}, style Style) StyledValue {
+	return StyledValue{
+		value:     value,
+		style:     style,
+		colorable: c,
+	}
+}
+
+// Format formats the value by the verb, the precision and the flags, then wraps it with the style.
+// The width pads the styled value by spaces outside the style, or by zeros inside it, as in %05d.
+func (sv StyledValue) Format(fs fmt.State, verb rune) {
+	colorable := sv.colorable
+	if colorable == nil {
+		colorable = NewColorable(nil)
+	}
+
+	width, hasWidth := fs.Width()
+	isZeroPadded := fs.Flag('0') && !fs.Flag('-')
+	formatted := fmt.Sprintf(formatDirective(fs, verb, isZeroPadded), sv.value)
+	styled := colorable.Sprint(sv.style, formatted)
+
+	if !hasWidth || isZeroPadded {
+		fmt.Fprint(fs, styled)
+
+		return
+	}
+
+	padding := strings.Repeat(" ", paddingWidth(formatted, width))
+	if fs.Flag('-') {
+		fmt.Fprint(fs, styled, padding)
+
+		return
+	}
+	fmt.Fprint(fs, padding, styled)
+}
+
+// String returns the value formatted by %v, and wrapped with the style.
+func (sv StyledValue) String() string {
+	return fmt.Sprint(sv)
+}
+
+// formatDirective rebuilds the format directive of the state, as in %-10.2f.
+func formatDirective(fs fmt.State, verb rune, withWidth bool) string {
+	directive := []byte{'%'}
+	for _, flag := range formatFlags {
+		if fs.Flag(int(flag)) {
+			directive = append(directive, byte(flag))
+		}
+	}
+
+	if width, ok := fs.Width(); ok && withWidth {
+		directive = strconv.AppendInt(directive, int64(width), 10)
+	}
+
+	if precision, ok := fs.Precision(); ok {
+		directive = append(directive, '.')
+		directive = strconv.AppendInt(directive, int64(precision), 10)
+	}
+
+	return string(directive) + string(verb)
+}
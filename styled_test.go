@@ -0,0 +1,76 @@
+package colorize
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStyledValueFormat(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(nil).EnableColor()
+	bold := Style{Font: Fonts(Bold)}
+
+	testCases := []struct {
+		id       string
+		format   string
+		values   []interface{}
+		expected string
+	}{
+		{
+			id:       "Should format the value by the verb.",
+			format:   "%v",
+			values:   []interface{}{42},
+			expected: "\x1b[1m42\x1b[0m",
+		},
+		{
+			id:       "Should apply the precision and the flags.",
+			format:   "%+.2f|%#x|%q",
+			values:   []interface{}{3.14159, 255, "a"},
+			expected: "\x1b[1m+3.14\x1b[0m|\x1b[1m0xff\x1b[0m|\x1b[1m\"a\"\x1b[0m",
+		},
+		{
+			id:       "Should pad to the left by the visible width.",
+			format:   "%6s|",
+			values:   []interface{}{"日本"},
+			expected: "  \x1b[1m日本\x1b[0m|",
+		},
+		{
+			id:       "Should pad to the right outside the style.",
+			format:   "%-5v|",
+			values:   []interface{}{"ab"},
+			expected: "\x1b[1mab\x1b[0m   |",
+		},
+		{
+			id:       "Should pad the styled values by their visible width.",
+			format:   "%-5v|",
+			values:   []interface{}{"\x1b[31mab\x1b[0m"},
+			expected: "\x1b[1m\x1b[31mab\x1b[0m\x1b[0m   |",
+		},
+		{
+			id:       "Should pad by zeros inside the style.",
+			format:   "%05d",
+			values:   []interface{}{42},
+			expected: "\x1b[1m00042\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			styled := make([]interface{}, len(testCase.values))
+			for index, value := range testCase.values {
+				styled[index] = colorized.Styled(value, bold)
+			}
+
+			assert.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, styled...))
+		})
+	}
+}
+
+func TestStyled(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "\x1b[1mtext\x1b[0m", Styled("text", Style{Font: Fonts(Bold)}).String())
+	assert.Equal(t, "text  ", fmt.Sprintf("%-6v", NewColorable(nil).DisableColor().Styled("text", Style{Font: Fonts(Bold)})))
+}
@@ -0,0 +1,219 @@
+package colorize
+
+import (
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// SVGOptions of the SVG rendering, the zero value renders with the defaults.
+	SVGOptions struct {
+		// Columns of the terminal, wrapping the longer rows, defaults to the widest row.
+		Columns int
+		// FontFamily of the text, defaults to "monospace".
+		FontFamily string
+		// FontSize in pixels, defaults to 14, the cells are 0.6 of it wide and 1.2 of it high.
+		FontSize float64
+		// Padding around the cells in pixels, defaults to 12.
+		Padding float64
+		// Palette resolving the indexed colors, the zero value falls back to the xterm colors.
+		Palette Palette
+		// Foreground and Background of the unstyled text, defaults to the palette foreground and background.
+		Foreground Color
+		Background Color
+		// Window draws a window frame around the cells, with the given title.
+		Window bool
+		Title  string
+	}
+
+	// svgRenderer writes a screen into an SVG document.
+	svgRenderer struct {
+		options    SVGOptions
+		builder    strings.Builder
+		cellWidth  float64
+		lineHeight float64
+		top        float64
+	}
+)
+
+const (
+	defaultFontFamily  = "monospace"
+	defaultFontSize    = 14
+	defaultPadding     = 12
+	cellWidthRatio     = 0.6
+	lineHeightRatio    = 1.2
+	windowFrameHeight  = 32
+	windowCornerRadius = 6
+)
+
+// RenderSVG draws the styled text as an SVG terminal snapshot, e.g. to generate the screenshots of a CLI.
+// e.g.: RenderSVG(file, colorized.Sprint(style, "text"), SVGOptions{Window: true})
+func RenderSVG(w io.Writer, input string, options SVGOptions) error {
+	r := newSVGRenderer(options)
+	r.render(newScreen(NewParser(r.options.Palette).Parse(input), r.options.Columns))
+
+	_, err := io.WriteString(w, r.builder.String())
+
+	return err
+}
+
+func newSVGRenderer(options SVGOptions) *svgRenderer {
+	if options.FontFamily == "" {
+		options.FontFamily = defaultFontFamily
+	}
+	if options.FontSize <= 0 {
+		options.FontSize = defaultFontSize
+	}
+	if options.Padding <= 0 {
+		options.Padding = defaultPadding
+	}
+	if options.Foreground == nil {
+		options.Foreground = options.Palette.DefaultForeground()
+	}
+	if options.Background == nil {
+		options.Background = options.Palette.DefaultBackground()
+	}
+
+	r := &svgRenderer{
+		options:    options,
+		cellWidth:  options.FontSize * cellWidthRatio,
+		lineHeight: options.FontSize * lineHeightRatio,
+	}
+	if options.Window {
+		r.top = windowFrameHeight
+	}
+
+	return r
+}
+
+func (r *svgRenderer) render(sc screen) {
+	width := 2*r.options.Padding + float64(sc.columns)*r.cellWidth
+	height := r.top + 2*r.options.Padding + float64(len(sc.rows))*r.lineHeight
+
+	r.write(`<svg xmlns="http://www.w3.org/2000/svg" width="`, formatFloat(width), `" height="`, formatFloat(height),
+		`" viewBox="0 0 `, formatFloat(width), " ", formatFloat(height), `">`, "\n")
+	r.writeFrame(width, height)
+	r.write(`<g font-family="`, escapeXML(r.options.FontFamily), `" font-size="`,
+		formatFloat(r.options.FontSize), `" xml:space="preserve">`, "\n")
+
+	for row, runs := range sc.rows {
+		for _, run := range runs {
+			r.writeBackground(row, run)
+		}
+	}
+
+	for row, runs := range sc.rows {
+		for _, run := range runs {
+			r.writeText(row, run)
+		}
+	}
+
+	r.write("</g>\n</svg>\n")
+}
+
+// writeFrame writes the background, and the window frame if enabled.
+func (r *svgRenderer) writeFrame(width, height float64) {
+	radius := "0"
+	if r.options.Window {
+		radius = strconv.Itoa(windowCornerRadius)
+	}
+	r.write(`<rect width="`, formatFloat(width), `" height="`, formatFloat(height), `" rx="`, radius,
+		`" fill="`, r.options.Background.Hex(), `"/>`, "\n")
+
+	if !r.options.Window {
+		return
+	}
+
+	for index, fill := range []string{"#ff5f57", "#febc2e", "#28c840"} {
+		r.write(`<circle cx="`, strconv.Itoa(18+index*20), `" cy="16" r="6" fill="`, fill, `"/>`, "\n")
+	}
+
+	if r.options.Title != "" {
+		r.write(`<text x="`, formatFloat(width/2), `" y="20" text-anchor="middle" font-family="sans-serif" font-size="12" fill="`,
+			r.options.Foreground.Hex(), `">`, escapeXML(r.options.Title), "</text>\n")
+	}
+}
+
+func (r *svgRenderer) writeBackground(row int, run cellRun) {
+	_, background := resolveColors(run.style, r.options.Foreground, r.options.Background)
+	if background.Equals(r.options.Background) {
+		return
+	}
+
+	r.write(`<rect x="`, formatFloat(r.x(run.column)), `" y="`, formatFloat(r.y(row)),
+		`" width="`, formatFloat(float64(run.width)*r.cellWidth), `" height="`, formatFloat(r.lineHeight),
+		`" fill="`, background.Hex(), `"/>`, "\n")
+}
+
+func (r *svgRenderer) writeText(row int, run cellRun) {
+	if run.style.Font.Has(Concealed) || strings.TrimSpace(run.text) == "" {
+		return
+	}
+
+	foreground, _ := resolveColors(run.style, r.options.Foreground, r.options.Background)
+	r.write(`<text x="`, formatFloat(r.x(run.column)), `" y="`, formatFloat(r.y(row)+r.options.FontSize),
+		`" textLength="`, formatFloat(float64(run.width)*r.cellWidth), `" lengthAdjust="spacingAndGlyphs" fill="`,
+		foreground.Hex(), `"`)
+
+	if run.style.Font.Has(Bold) {
+		r.write(` font-weight="bold"`)
+	}
+	if run.style.Font.Has(Faint) {
+		r.write(` opacity="0.7"`)
+	}
+	if run.style.Font.Has(Italic) {
+		r.write(` font-style="italic"`)
+	}
+	if decorations := textDecorationLines(run.style); len(decorations) > 0 {
+		r.write(` text-decoration="`, strings.Join(decorations, " "), `"`)
+	}
+
+	r.write(">", escapeXML(run.text), "</text>\n")
+}
+
+// x returns the left of the column.
+func (r *svgRenderer) x(column int) float64 {
+	return r.options.Padding + float64(column)*r.cellWidth
+}
+
+// y returns the top of the row.
+func (r *svgRenderer) y(row int) float64 {
+	return r.top + r.options.Padding + float64(row)*r.lineHeight
+}
+
+func (r *svgRenderer) write(s ...string) {
+	for _, part := range s {
+		r.builder.WriteString(part)
+	}
+}
+
+// formatFloat formats the value rounded to two decimals, with the fewest needed digits, as in 8.4.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// escapeXML escapes s for the SVG text and attributes, replacing each invalid UTF-8 byte,
+// and each control character not allowed by XML, by "\uFFFD", so the document stays well-formed.
+func escapeXML(s string) string {
+	var builder strings.Builder
+	builder.Grow(len(s))
+
+	for index := 0; index < len(s); {
+		character, size := utf8.DecodeRuneInString(s[index:])
+		index += size
+
+		switch {
+		case character == utf8.RuneError && size == 1,
+			character < ' ' && character != '\t' && character != '\n' && character != '\r':
+			builder.WriteRune(utf8.RuneError)
+		default:
+			builder.WriteRune(character)
+		}
+	}
+
+	return html.EscapeString(builder.String())
+}
@@ -0,0 +1,95 @@
+package colorize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestRenderSVG(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		options  SVGOptions
+		expected string
+	}{
+		{
+			id:      "Should render the styled cells.",
+			input:   "\x1b[1;31mok\x1b[0m \x1b[44;4;3m<x>\x1b[0m\x1b[8mhidden\n",
+			options: SVGOptions{FontSize: 10, Padding: 5, Foreground: RGB(255, 255, 255)},
+			expected: `<svg xmlns="http://www.w3.org/2000/svg" width="82" height="22" viewBox="0 0 82 22">
+<rect width="82" height="22" rx="0" fill="#000000"/>
+<g font-family="monospace" font-size="10" xml:space="preserve">
+<rect x="23" y="5" width="18" height="12" fill="#0000ee"/>
+<text x="5" y="15" textLength="12" lengthAdjust="spacingAndGlyphs" fill="#cd0000" font-weight="bold">ok</text>
+<text x="23" y="15" textLength="18" lengthAdjust="spacingAndGlyphs" fill="#ffffff" font-style="italic" text-decoration="underline">&lt;x&gt;</text>
+</g>
+</svg>
+`,
+		},
+		{
+			id:      "Should render the window frame with the title.",
+			input:   "\x1b[7mreversed",
+			options: SVGOptions{FontSize: 10, Padding: 5, Window: true, Title: "demo"},
+			expected: `<svg xmlns="http://www.w3.org/2000/svg" width="58" height="54" viewBox="0 0 58 54">
+<rect width="58" height="54" rx="6" fill="#000000"/>
+<circle cx="18" cy="16" r="6" fill="#ff5f57"/>
+<circle cx="38" cy="16" r="6" fill="#febc2e"/>
+<circle cx="58" cy="16" r="6" fill="#28c840"/>
+<text x="29" y="20" text-anchor="middle" font-family="sans-serif" font-size="12" fill="#e5e5e5">demo</text>
+<g font-family="monospace" font-size="10" xml:space="preserve">
+<rect x="5" y="37" width="48" height="12" fill="#e5e5e5"/>
+<text x="5" y="47" textLength="48" lengthAdjust="spacingAndGlyphs" fill="#000000">reversed</text>
+</g>
+</svg>
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+
+			assert.NoError(t, RenderSVG(output, testCase.input, testCase.options))
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestRenderSVGError(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, RenderSVG(failingWriter{}, "text", SVGOptions{}))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestRenderSVGInvalidText(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	assert.NoError(t, RenderSVG(output, "bad \xff\xfe bytes", SVGOptions{Window: true, Title: "log\x01"}))
+
+	decoder := xml.NewDecoder(output)
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+	}
+
+	assert.Equal(t, "�", escapeXML("\xff"))
+	assert.Equal(t, "log� &amp; �tab\t", escapeXML("log\x01 & \x1btab\t"))
+}
@@ -0,0 +1,80 @@
+package colorize
+
+import (
+	"text/template"
+)
+
+// FuncMap returns the template functions styling their arguments through the Colorable,
+// so they follow its DisableColor() and profile settings:
+//   - color "red" and bg "#abc" apply a foreground or a background color, named, hex or CSS rgb().
+//   - hex "#abc" applies a hex foreground color.
+//   - style "error" applies a theme role, or the styles of a markup tag, as in style "bold red".
+//   - bold, dim, italic, underline, strike and reverse apply the font effects.
+//   - black, blue, cyan, gray, green, magenta, orange, purple, red, white and yellow act as Colorable.Red().
+//   - markup renders a markup, see Colorable.Markup().
+//
+// e.g.: template.New("help").Funcs(FuncMap(colorized)).Parse(`{{ .Name | bold }} {{ .Path | color "cyan" }}`)
+// It works with html/template as well, converted by htmltemplate.FuncMap(FuncMap(colorized)).
+func FuncMap(c *Colorable) template.FuncMap {
+	return template.FuncMap{
+		"color": func(color string, s ...interface{}) (string, error) {
+			foreground, err := c.parseMarkupColor(color)
+			if err != nil {
+				return "", err
+			}
+
+			return c.Sprint(Style{Foreground: foreground}, s...), nil
+		},
+		"bg": func(color string, s ...interface{}) (string, error) {
+			background, err := c.parseMarkupColor(color)
+			if err != nil {
+				return "", err
+			}
+
+			return c.Sprint(Style{Background: background}, s...), nil
+		},
+		"hex": func(color string, s ...interface{}) (string, error) {
+			foreground, err := Hex(color)
+			if err != nil {
+				return "", err
+			}
+
+			return c.Sprint(Style{Foreground: foreground}, s...), nil
+		},
+		"style": func(name string, s ...interface{}) (string, error) {
+			style, err := c.newMarkupParser(true).parseStyle(name)
+			if err != nil {
+				return "", err
+			}
+
+			return c.Sprint(style, s...), nil
+		},
+		"bold":      fontFunc(c, Bold),
+		"dim":       fontFunc(c, Faint),
+		"italic":    fontFunc(c, Italic),
+		"underline": fontFunc(c, Underline),
+		"strike":    fontFunc(c, CrossedOut),
+		"reverse":   fontFunc(c, ReverseVideo),
+		"black":     c.Black,
+		"blue":      c.Blue,
+		"cyan":      c.Cyan,
+		"gray":      c.Gray,
+		"green":     c.Green,
+		"magenta":   c.Magenta,
+		"orange":    c.Orange,
+		"purple":    c.Purple,
+		"red":       c.Red,
+		"white":     c.White,
+		"yellow":    c.Yellow,
+		"markup":    c.Markup,
+	}
+}
+
+// fontFunc returns a template function applying the font effect.
+func fontFunc(c *Colorable, effect FontEffect) func(s ...interface{}) string {
+	style := Style{Font: Fonts(effect)}
+
+	return func(s ...interface{}) string {
+		return c.Sprint(style, s...)
+	}
+}
@@ -0,0 +1,89 @@
+package colorize
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	htmlTemplate "html/template"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(nil).EnableColor().DefineStyle("path", Style{Font: Fonts(Underline)})
+
+	testCases := []struct {
+		id       string
+		template string
+		expected string
+	}{
+		{
+			id:       "Should apply the named colors.",
+			template: `{{ "text" | color "red" }}`,
+			expected: "\x1b[38;2;255;0;0mtext\x1b[0m",
+		},
+		{
+			id:       "Should apply the background colors.",
+			template: `{{ bg "rgb(1, 2, 3)" "text" }}`,
+			expected: "\x1b[48;2;1;2;3mtext\x1b[0m",
+		},
+		{
+			id:       "Should apply the hex colors.",
+			template: `{{ hex "#abc" "text" }}`,
+			expected: "\x1b[38;2;170;187;204mtext\x1b[0m",
+		},
+		{
+			id:       "Should apply the named styles and the markup tags.",
+			template: `{{ style "path" "a" }} {{ style "bold cyan" "b" }}`,
+			expected: "\x1b[4ma\x1b[0m \x1b[38;2;0;255;255;1mb\x1b[0m",
+		},
+		{
+			id:       "Should apply the font effects.",
+			template: `{{ bold "a" }}{{ italic "b" }}{{ "c" | strike }}`,
+			expected: "\x1b[1ma\x1b[0m\x1b[3mb\x1b[0m\x1b[9mc\x1b[0m",
+		},
+		{
+			id:       "Should apply the direct color helpers.",
+			template: `{{ green "ok" }}`,
+			expected: "\x1b[38;2;0;255;0mok\x1b[0m",
+		},
+		{
+			id:       "Should render the markup.",
+			template: `{{ markup "[bold]a[/] b" }}`,
+			expected: "\x1b[1ma\x1b[0m b",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			tmpl := template.Must(template.New(testCase.id).Funcs(FuncMap(colorized)).Parse(testCase.template))
+
+			assert.NoError(t, tmpl.Execute(output, nil))
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestFuncMapError(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("error").Funcs(FuncMap(NewColorable(nil))).Parse(`{{ color "unknown" "text" }}`))
+
+	assert.Error(t, tmpl.Execute(&bytes.Buffer{}, nil))
+}
+
+func TestFuncMapDisabledColor(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	tmpl := htmlTemplate.Must(
+		htmlTemplate.New("html").
+			Funcs(htmlTemplate.FuncMap(FuncMap(NewColorable(nil).DisableColor()))).
+			Parse(`<b>{{ "a&b" | bold }}</b>`),
+	)
+
+	assert.NoError(t, tmpl.Execute(output, nil))
+	assert.Equal(t, "<b>a&amp;b</b>", output.String())
+}
@@ -0,0 +1,184 @@
+package colorize
+
+import "io"
+
+type (
+	// Theme maps the semantic roles to styles, so the output is re-themed in a single place,
+	// e.g.: colorized.SetTheme(LightTheme.With("path", Style{Font: Fonts(Underline)}))
+	Theme map[string]Style
+
+	// Role prints with the style of a theme role, see Colorable.Role().
+	Role struct {
+		name      string
+		style     Style
+		colorable *Colorable
+	}
+)
+
+// Built-in roles of the themes.
+const (
+	ErrorRole   = "error"
+	WarningRole = "warning"
+	SuccessRole = "success"
+	InfoRole    = "info"
+	MutedRole   = "muted"
+	AccentRole  = "accent"
+	HeadingRole = "heading"
+	CodeRole    = "code"
+	LinkRole    = "link"
+)
+
+var (
+	// DarkTheme of the terminals with a dark background.
+	DarkTheme = Theme{
+		ErrorRole:   {Foreground: RGB(255, 85, 85), Font: Fonts(Bold)},
+		WarningRole: {Foreground: RGB(255, 204, 0)},
+		SuccessRole: {Foreground: RGB(80, 250, 123)},
+		InfoRole:    {Foreground: RGB(98, 174, 239)},
+		MutedRole:   {Foreground: RGB(128, 128, 128)},
+		AccentRole:  {Foreground: RGB(198, 120, 221)},
+		HeadingRole: {Foreground: RGB(255, 255, 255), Font: Fonts(Bold, Underline)},
+		CodeRole:    {Foreground: RGB(229, 192, 123)},
+		LinkRole:    {Foreground: RGB(97, 175, 239), Font: Fonts(Underline)},
+	}
+
+	// LightTheme of the terminals with a light background.
+	LightTheme = Theme{
+		ErrorRole:   {Foreground: RGB(200, 0, 0), Font: Fonts(Bold)},
+		WarningRole: {Foreground: RGB(176, 112, 0)},
+		SuccessRole: {Foreground: RGB(0, 128, 0)},
+		InfoRole:    {Foreground: RGB(0, 92, 197)},
+		MutedRole:   {Foreground: RGB(110, 110, 110)},
+		AccentRole:  {Foreground: RGB(136, 57, 239)},
+		HeadingRole: {Foreground: RGB(0, 0, 0), Font: Fonts(Bold, Underline)},
+		CodeRole:    {Foreground: RGB(166, 38, 164)},
+		LinkRole:    {Foreground: RGB(0, 92, 197), Font: Fonts(Underline)},
+	}
+
+	// DefaultTheme of the Colorable instances without a theme set.
+	DefaultTheme = DarkTheme
+)
+
+// With returns a copy of the theme, with the role style added or replaced.
+func (t Theme) With(role string, style Style) Theme {
+	theme := t.clone()
+	theme[role] = style
+
+	return theme
+}
+
+func (t Theme) clone() Theme {
+	theme := make(Theme, len(t)+1)
+	for role, style := range t {
+		theme[role] = style
+	}
+
+	return theme
+}
+
+// Theme returns a copy of the Colorable theme.
+func (c *Colorable) Theme() Theme {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.currentTheme().clone()
+}
+
+// SetTheme sets the theme of the roles, used by Role() and the markup tags.
+func (c *Colorable) SetTheme(theme Theme) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.theme = theme.clone()
+
+	return c
+}
+
+// DefineStyle adds or replaces the role style in the Colorable theme, to be used by Role() and the markup tags.
+func (c *Colorable) DefineStyle(name string, style Style) *Colorable {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.theme == nil {
+		c.theme = DefaultTheme.clone()
+	}
+	c.theme[name] = style
+
+	return c
+}
+
+// NamedStyle returns the style of the given role, and false if the theme does not define it.
+func (c *Colorable) NamedStyle(name string) (Style, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	style, ok := c.currentTheme()[name]
+
+	return style, ok
+}
+
+// Role returns the role of the theme, having the zero Style if the theme does not define it.
+// e.g.: colorized.Role(ErrorRole).Sprint("failed")
+func (c *Colorable) Role(name string) Role {
+	style, _ := c.NamedStyle(name)
+
+	return Role{
+		name:      name,
+		style:     style,
+		colorable: c,
+	}
+}
+
+// currentTheme is called with c.mux held.
+func (c *Colorable) currentTheme() Theme {
+	if c.theme == nil {
+		return DefaultTheme
+	}
+
+	return c.theme
+}
+
+// Name returns the role name.
+func (r Role) Name() string {
+	return r.name
+}
+
+// Style returns the role style.
+func (r Role) Style() Style {
+	return r.style
+}
+
+// Fprint acts as the standard fmt.Fprint() method, wrapped with the role style.
+func (r Role) Fprint(w io.Writer, s ...interface{}) (n int, err error) {
+	return r.colorable.Fprint(w, r.style, s...)
+}
+
+// Print acts as the standard fmt.Print() method, wrapped with the role style.
+func (r Role) Print(s ...interface{}) (n int, err error) {
+	return r.colorable.Print(r.style, s...)
+}
+
+// Printf acts as the standard fmt.Printf() method, wrapped with the role style.
+func (r Role) Printf(format string, s ...interface{}) (n int, err error) {
+	return r.colorable.Printf(r.style, format, s...)
+}
+
+// Println acts as the standard fmt.Println() method, wrapped with the role style.
+func (r Role) Println(s ...interface{}) (n int, err error) {
+	return r.colorable.Println(r.style, s...)
+}
+
+// Sprint acts as the standard fmt.Sprint() method, wrapped with the role style.
+func (r Role) Sprint(s ...interface{}) string {
+	return r.colorable.Sprint(r.style, s...)
+}
+
+// Sprintf acts as the standard fmt.Sprintf() method, wrapped with the role style.
+func (r Role) Sprintf(format string, s ...interface{}) string {
+	return r.colorable.Sprintf(r.style, format, s...)
+}
+
+// Sprintln acts as the standard fmt.Sprintln() method, wrapped with the role style.
+func (r Role) Sprintln(s ...interface{}) string {
+	return r.colorable.Sprintln(r.style, s...)
+}
@@ -0,0 +1,100 @@
+package colorize
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRole(t *testing.T) {
+	t.Parallel()
+
+	path := Style{Font: Fonts(Underline)}
+
+	testCases := []struct {
+		id        string
+		colorable *Colorable
+		role      string
+		expected  string
+	}{
+		{
+			id:        "Should apply the default theme role.",
+			colorable: NewColorable(nil).EnableColor(),
+			role:      ErrorRole,
+			expected:  "\x1b[38;2;255;85;85;1mfailed\x1b[0m",
+		},
+		{
+			id:        "Should apply the set theme role.",
+			colorable: NewColorable(nil).EnableColor().SetTheme(LightTheme),
+			role:      SuccessRole,
+			expected:  "\x1b[38;2;0;128;0mfailed\x1b[0m",
+		},
+		{
+			id:        "Should apply the user-defined role.",
+			colorable: NewColorable(nil).EnableColor().SetTheme(LightTheme.With("path", path)),
+			role:      "path",
+			expected:  "\x1b[4mfailed\x1b[0m",
+		},
+		{
+			id:        "Should apply the zero style for the undefined role.",
+			colorable: NewColorable(nil).EnableColor().SetTheme(Theme{}),
+			role:      ErrorRole,
+			expected:  "\x1b[mfailed\x1b[0m",
+		},
+		{
+			id:        "Should not style with the color disabled.",
+			colorable: NewColorable(nil).DisableColor(),
+			role:      ErrorRole,
+			expected:  "failed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			role := testCase.colorable.Role(testCase.role)
+
+			assert.Equal(t, testCase.role, role.Name())
+			assert.Equal(t, testCase.expected, role.Sprint("failed"))
+		})
+	}
+}
+
+func TestRolePrint(t *testing.T) {
+	t.Parallel()
+
+	output := &bytes.Buffer{}
+	role := NewColorable(output).EnableColor().DefineStyle("muted", Style{Font: Fonts(Faint)}).Role(MutedRole)
+
+	role.Printf("%d", 1)
+	role.Println("b")
+
+	assert.Equal(t, "\x1b[2m1\x1b[0m\x1b[2mb\n\x1b[0m", output.String())
+}
+
+func TestTheme(t *testing.T) {
+	t.Parallel()
+
+	theme := Theme{InfoRole: {Font: Fonts(Bold)}}
+	colorized := NewColorable(nil).SetTheme(theme)
+	theme[InfoRole] = Style{}
+	colorized.Theme()[InfoRole] = Style{}
+
+	style, ok := colorized.NamedStyle(InfoRole)
+	assert.True(t, ok)
+	assert.Equal(t, Style{Font: Fonts(Bold)}, style, "Should not be changed through the set or the returned themes.")
+
+	colorized = NewColorable(nil).DefineStyle("path", Style{Font: Fonts(Italic)})
+	_, ok = colorized.NamedStyle("path")
+	assert.True(t, ok)
+	_, ok = NewColorable(nil).NamedStyle("path")
+	assert.False(t, ok, "Should not define the style into the default theme.")
+	assert.Equal(t, DarkTheme[HeadingRole], colorized.Role(HeadingRole).Style(), "Should keep the default roles.")
+}
+
+func TestMarkupRoles(t *testing.T) {
+	t.Parallel()
+
+	colorized := NewColorable(nil).EnableColor().SetTheme(Theme{ErrorRole: {Foreground: RGB(1, 2, 3)}})
+
+	assert.Equal(t, "\x1b[38;2;1;2;3;1mfailed\x1b[0m", colorized.Markup("[error bold]failed[/]"))
+}
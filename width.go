@@ -0,0 +1,394 @@
+package colorize
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// activeSequences tracks the style and the OSC 8 link in effect,
+	// to close and apply them again around the inserted line breaks.
+	activeSequences struct {
+		// parser keeps the style set by the SGR sequences, rather than the sequences themselves.
+		parser Parser
+		link   []byte
+	}
+
+	// wrapper breaks the words of a text into lines, see Wrap().
+	wrapper struct {
+		width       int
+		output      []byte
+		lineWidth   int
+		spaces      []byte
+		spacesWidth int
+		word        []byte
+		wordWidth   int
+		// active sequences at the current position, and before the pending word.
+		active     activeSequences
+		wordActive activeSequences
+	}
+)
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	emojiPresentation = '\ufe0f'
+)
+
+// wideTable of the East Asian wide and full width characters, including the emoji presentation ones.
+var wideTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f3, Stride: 3},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x2693, Stride: 20},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26d4, Stride: 6},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26fa, Stride: 5},
+		{Lo: 0x26fd, Hi: 0x2705, Stride: 8},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x274c, Stride: 36},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27bf, Stride: 15},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 5},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18aff, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f202, Stride: 1},
+		{Lo: 0x1f210, Hi: 0x1f23b, Stride: 1},
+		{Lo: 0x1f240, Hi: 0x1f248, Stride: 1},
+		{Lo: 0x1f250, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f260, Hi: 0x1f265, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
+
+// Width returns the number of the terminal cells taken by s, ignoring the escape sequences,
+// the wide characters take two cells, the combining marks and the joined emojis take none.
+// e.g.: Width("\x1b[1m日本\x1b[0m") -> 4
+func Width(s string) int {
+	width := 0
+	forEachCluster(
+		s,
+		func([]byte) {},
+		func(_ []byte, clusterWidth int) {
+			width += clusterWidth
+		},
+	)
+
+	return width
+}
+
+// Truncate shortens s to the given width, ending it with the tail, e.g. "…".
+// The escape sequences after the cut point are kept, so the styles are closed as before.
+// An empty string is returned for a width that is not positive.
+func Truncate(s string, width int, tail string) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if Width(s) <= width {
+		return s
+	}
+
+	tailWidth := Width(tail)
+	if tailWidth > width {
+		tail = Truncate(tail, width, "")
+		tailWidth = Width(tail)
+	}
+
+	truncated := make([]byte, 0, len(s)+len(tail))
+	truncatedWidth, isCut := 0, false
+	forEachCluster(
+		s,
+		func(sequence []byte) {
+			truncated = append(truncated, sequence...)
+		},
+		func(cluster []byte, clusterWidth int) {
+			if isCut {
+				return
+			}
+
+			if truncatedWidth+clusterWidth > width-tailWidth {
+				truncated = append(truncated, tail...)
+				isCut = true
+
+				return
+			}
+			truncated = append(truncated, cluster...)
+			truncatedWidth += clusterWidth
+		},
+	)
+
+	return string(truncated)
+}
+
+// PadRight appends spaces to s till the given width.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", paddingWidth(s, width))
+}
+
+// PadLeft prepends spaces to s till the given width.
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", paddingWidth(s, width)) + s
+}
+
+// Center surrounds s with spaces till the given width, the odd space goes to the right.
+func Center(s string, width int) string {
+	padding := paddingWidth(s, width)
+
+	return strings.Repeat(" ", padding/2) + s + strings.Repeat(" ", padding-padding/2)
+}
+
+// Wrap breaks s into lines of the given width at the spaces, and within the words longer than the width.
+// The styles and links applied at a break are closed before it, and applied again after it,
+// the style as a single sequence, with its indexed colors resolved by the DefaultPalette.
+func Wrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	w := wrapper{
+		width:      width,
+		output:     make([]byte, 0, len(s)),
+		active:     activeSequences{parser: Parser{palette: DefaultPalette}},
+		wordActive: activeSequences{parser: Parser{palette: DefaultPalette}},
+	}
+	forEachCluster(s, w.appendSequence, w.appendCluster)
+	w.flushWord()
+
+	if w.lineWidth+w.spacesWidth <= width {
+		w.output = append(w.output, w.spaces...)
+	}
+
+	return string(w.output)
+}
+
+func paddingWidth(s string, width int) int {
+	if padding := width - Width(s); padding > 0 {
+		return padding
+	}
+
+	return 0
+}
+
+// forEachCluster calls sequence for each escape sequence of s,
+// and cluster for each of its user perceived characters with its width.
+func forEachCluster(s string, sequence func([]byte), cluster func([]byte, int)) {
+	scanner := sequenceScanner{}
+	scanner.scan(
+		[]byte(s),
+		func(text []byte) {
+			for len(text) > 0 {
+				size, width := nextCluster(text)
+				cluster(text[:size], width)
+				text = text[size:]
+			}
+		},
+		sequence,
+	)
+}
+
+// nextCluster returns the size and the width of the first cluster of p, as a base character
+// followed by its combining marks, variation selectors, emoji modifiers and joined characters.
+func nextCluster(p []byte) (size, width int) {
+	char, size := utf8.DecodeRune(p)
+	if char < 0x20 || char == 0x7f {
+		if char == '\r' && len(p) > 1 && p[1] == '\n' {
+			return 2, 0
+		}
+
+		return size, 0
+	}
+
+	width = runeWidth(char)
+	isRegional := isRegionalIndicator(char)
+
+	for size < len(p) {
+		next, nextSize := utf8.DecodeRune(p[size:])
+
+		switch {
+		case next == zeroWidthJoiner:
+			size += nextSize
+			if size < len(p) {
+				_, joinedSize := utf8.DecodeRune(p[size:])
+				size += joinedSize
+			}
+		case next == emojiPresentation:
+			size += nextSize
+			if width == 1 {
+				width = 2
+			}
+		case isRegional && isRegionalIndicator(next):
+			// A pair of regional indicators renders as a flag.
+			size += nextSize
+			width, isRegional = 2, false
+		case isEmojiModifier(next) || (runeWidth(next) == 0 && next >= 0x20):
+			size += nextSize
+		default:
+			return size, width
+		}
+	}
+
+	return size, width
+}
+
+// runeWidth returns the number of the terminal cells taken by the character on its own.
+func runeWidth(char rune) int {
+	switch {
+	case char < 0x20 || (char >= 0x7f && char < 0xa0):
+		return 0
+	case char < 0x300:
+		return 1
+	case unicode.In(char, unicode.Mn, unicode.Me, unicode.Cf),
+		char >= 0x1160 && char <= 0x11ff,
+		char >= 0xd7b0 && char <= 0xd7ff:
+		// Combining marks, format characters and the Hangul medial vowels and final consonants.
+		return 0
+	case unicode.Is(wideTable, char):
+		return 2
+	}
+
+	return 1
+}
+
+func isRegionalIndicator(char rune) bool {
+	return char >= 0x1f1e6 && char <= 0x1f1ff
+}
+
+// isEmojiModifier returns true for the skin tone modifiers.
+func isEmojiModifier(char rune) bool {
+	return char >= 0x1f3fb && char <= 0x1f3ff
+}
+
+// apply updates the active sequences by the given one.
+func (as *activeSequences) apply(sequence []byte) {
+	switch {
+	case len(sequence) > 2 && sequence[1] == csiIntroducer && sequence[len(sequence)-1] == sgrSuffix:
+		as.parser.applySequence(sequence)
+	case bytes.HasPrefix(sequence, []byte(hyperlinkPrefix)):
+		if parseLink(sequence[len(hyperlinkPrefix):]).IsZero() {
+			as.link = as.link[:0]
+
+			return
+		}
+		as.link = append(as.link[:0], sequence...)
+	}
+}
+
+// copyFrom replaces the active sequences by the given ones, reusing the buffers.
+func (as *activeSequences) copyFrom(other activeSequences) {
+	as.parser.style = other.parser.style
+	as.link = append(as.link[:0], other.link...)
+}
+
+// appendBreak appends a line break, closing the active sequences before it and applying them after it.
+func (as *activeSequences) appendBreak(dst []byte) []byte {
+	if len(as.link) > 0 {
+		dst = append(dst, hyperlinkClose...)
+	}
+	style := as.parser.style
+	if !style.IsZero() {
+		dst = append(dst, resetSequence...)
+	}
+	dst = append(dst, '\n')
+	if !style.IsZero() {
+		dst = style.AppendSGR(dst)
+	}
+
+	return append(dst, as.link...)
+}
+
+func (w *wrapper) appendSequence(sequence []byte) {
+	if len(w.word) == 0 {
+		w.wordActive.copyFrom(w.active)
+	}
+	w.active.apply(sequence)
+	w.word = append(w.word, sequence...)
+}
+
+func (w *wrapper) appendCluster(cluster []byte, width int) {
+	switch {
+	case cluster[len(cluster)-1] == '\n':
+		w.flushWord()
+		w.output = append(w.output, w.spaces...)
+		w.output = append(w.output, cluster...)
+		w.lineWidth, w.spaces, w.spacesWidth = 0, w.spaces[:0], 0
+	case cluster[0] == ' ':
+		w.flushWord()
+		w.spaces = append(w.spaces, cluster...)
+		w.spacesWidth += width
+	default:
+		if w.wordWidth+width > w.width {
+			// The word does not fit on a line by its own.
+			w.flushWord()
+		}
+
+		if len(w.word) == 0 {
+			w.wordActive.copyFrom(w.active)
+		}
+		w.word = append(w.word, cluster...)
+		w.wordWidth += width
+	}
+}
+
+// flushWord outputs the pending spaces and word, breaking the line before them if the word does not fit.
+func (w *wrapper) flushWord() {
+	if w.wordWidth == 0 {
+		// Only sequences, the pending spaces are kept till the next word.
+		w.output = append(w.output, w.word...)
+		w.word = w.word[:0]
+
+		return
+	}
+
+	if w.lineWidth > 0 && w.lineWidth+w.spacesWidth+w.wordWidth > w.width {
+		w.output = w.wordActive.appendBreak(w.output)
+		w.lineWidth = 0
+	} else {
+		w.output = append(w.output, w.spaces...)
+		w.lineWidth += w.spacesWidth
+	}
+
+	w.output = append(w.output, w.word...)
+	w.lineWidth += w.wordWidth
+	w.word, w.wordWidth = w.word[:0], 0
+	w.spaces, w.spacesWidth = w.spaces[:0], 0
+}
@@ -0,0 +1,250 @@
+package colorize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		expected int
+	}{
+		{
+			id:       "Should count the ASCII characters.",
+			input:    "plain",
+			expected: 5,
+		},
+		{
+			id:       "Should ignore the escape sequences.",
+			input:    "\x1b[38;2;255;0;0;1mred\x1b[0m \x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+			expected: 8,
+		},
+		{
+			id:       "Should count the East Asian wide characters as two cells.",
+			input:    "日本語 ｶﾅ 한국",
+			expected: 14,
+		},
+		{
+			id:       "Should ignore the combining marks.",
+			input:    "e\u0301te\u0301",
+			expected: 3,
+		},
+		{
+			id:       "Should count an emoji ZWJ sequence as a single emoji.",
+			input:    "👩‍💻!",
+			expected: 3,
+		},
+		{
+			id:       "Should count the emoji modifiers, the flags and the emoji presentation.",
+			input:    "👍🏽🇩🇪❤️",
+			expected: 6,
+		},
+		{
+			id:       "Should ignore the control characters.",
+			input:    "a\r\nb\x00",
+			expected: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Width(testCase.input))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		width    int
+		tail     string
+		expected string
+	}{
+		{
+			id:       "Should return the text as is if it fits.",
+			input:    "\x1b[1mfits\x1b[0m",
+			width:    4,
+			tail:     "…",
+			expected: "\x1b[1mfits\x1b[0m",
+		},
+		{
+			id:       "Should truncate the text, keeping the sequences after the cut point.",
+			input:    "\x1b[1mhello \x1b[3mworld\x1b[0m",
+			width:    8,
+			tail:     "…",
+			expected: "\x1b[1mhello \x1b[3mw…\x1b[0m",
+		},
+		{
+			id:       "Should not split the wide characters.",
+			input:    "日本語",
+			width:    4,
+			tail:     ".",
+			expected: "日.",
+		},
+		{
+			id:       "Should not split the clusters.",
+			input:    "e\u0301e\u0301e\u0301",
+			width:    2,
+			tail:     "",
+			expected: "e\u0301e\u0301",
+		},
+		{
+			id:       "Should truncate the tail longer than the width.",
+			input:    "truncated",
+			width:    2,
+			tail:     "...",
+			expected: "..",
+		},
+		{
+			id:       "Should return an empty text for a zero width.",
+			input:    "\x1b[1mtruncated\x1b[0m",
+			width:    0,
+			tail:     "…",
+			expected: "",
+		},
+		{
+			id:       "Should return an empty text for a negative width.",
+			input:    "truncated",
+			width:    -1,
+			tail:     "…",
+			expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Truncate(testCase.input, testCase.width, testCase.tail))
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		pad      func(string, int) string
+		input    string
+		width    int
+		expected string
+	}{
+		{
+			id:       "Should pad the styled text to the right.",
+			pad:      PadRight,
+			input:    "\x1b[1mab\x1b[0m",
+			width:    5,
+			expected: "\x1b[1mab\x1b[0m   ",
+		},
+		{
+			id:       "Should pad the wide characters to the left.",
+			pad:      PadLeft,
+			input:    "日本",
+			width:    5,
+			expected: " 日本",
+		},
+		{
+			id:       "Should center the text.",
+			pad:      Center,
+			input:    "\x1b[1mab\x1b[0m",
+			width:    5,
+			expected: " \x1b[1mab\x1b[0m  ",
+		},
+		{
+			id:       "Should not pad the text wider than the width.",
+			pad:      Center,
+			input:    "wider",
+			width:    3,
+			expected: "wider",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.pad(testCase.input, testCase.width))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		id       string
+		input    string
+		width    int
+		expected string
+	}{
+		{
+			id:       "Should return the text as is if it fits.",
+			input:    "fits in",
+			width:    7,
+			expected: "fits in",
+		},
+		{
+			id:       "Should break the lines at the spaces.",
+			input:    "the quick brown fox",
+			width:    10,
+			expected: "the quick\nbrown fox",
+		},
+		{
+			id:       "Should keep the existing new lines.",
+			input:    "the\nquick brown fox",
+			width:    11,
+			expected: "the\nquick brown\nfox",
+		},
+		{
+			id:       "Should break the words longer than the width.",
+			input:    "a abcdefgh",
+			width:    3,
+			expected: "a\nabc\ndef\ngh",
+		},
+		{
+			id:       "Should break the wide characters.",
+			input:    "日本語",
+			width:    5,
+			expected: "日本\n語",
+		},
+		{
+			id:       "Should close and apply the styles again around the breaks.",
+			input:    "\x1b[1mthe \x1b[31mquick\x1b[0m brown",
+			width:    5,
+			expected: "\x1b[1mthe\x1b[0m\n\x1b[1m\x1b[31mquick\x1b[0m\nbrown",
+		},
+		{
+			id:       "Should apply the style left by a combined reset after the breaks.",
+			input:    "\x1b[1mbold \x1b[0;31mred word",
+			width:    4,
+			expected: "\x1b[1mbold\x1b[0m\n\x1b[1m\x1b[0;31mred\x1b[0m\n\x1b[38;2;205;0;0mword",
+		},
+		{
+			id:       "Should close and open the links around the breaks.",
+			input:    "\x1b]8;;https://example.com\x1b\\the link\x1b]8;;\x1b\\",
+			width:    4,
+			expected: "\x1b]8;;https://example.com\x1b\\the\x1b]8;;\x1b\\\n\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.id, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Wrap(testCase.input, testCase.width))
+		})
+	}
+}
+
+func TestWrapSequencesHistory(t *testing.T) {
+	t.Parallel()
+
+	input := "\x1b[32m" + strings.Repeat("\x1b[31mred \x1b[1mbold\x1b[22m ", 2000)
+	wrapped := Wrap(input, 8)
+
+	assert.True(t, len(wrapped) < 2*len(input), "wrapped to %d bytes", len(wrapped))
+	assert.Equal(t, 1999, strings.Count(wrapped, "\x1b[0m\n\x1b[38;2;205;0;0m\x1b[31mred"))
+}